@@ -22,6 +22,10 @@ var server_position: Vector2
 var _target_zoom := 2.0
 var _furthest_zoom_allowed := _target_zoom
 
+# Inputs sent to the server that it hasn't said it has applied yet, oldest first
+var _pending_inputs: Array[Dictionary] = []
+var _input_sequence := 0
+
 var velocity: Vector2
 var radius: float:
 	set(new_radius):
@@ -80,10 +84,44 @@ func _physics_process(delta: float) -> void:
 	
 	if abs(velocity.angle_to(input_vec)) > TAU / 15:
 		velocity = input_vec * speed
-		var packet = packets.Packet.new()
-		var player_direction_message := packet.new_player_direction()
-		player_direction_message.set_direction(velocity.angle())
-		WS.send(packet)
+		_send_direction(velocity.angle())
+
+# Inputs are numbered so the server can say which was the last one it applied. Numbers wrap
+# around and skip zero, which means an unnumbered input.
+func _send_direction(direction: float) -> void:
+	_input_sequence = _input_sequence % 0xFFFFFFFF + 1
+	var client_time := Time.get_ticks_msec() / 1000.0
+	_pending_inputs.append({"sequence": _input_sequence, "direction": direction, "time": client_time})
+	# A server that never says which inputs it applied mustn't have them pile up
+	if _pending_inputs.size() > 64:
+		_pending_inputs.pop_front()
+	
+	var packet := packets.Packet.new()
+	var player_direction_message := packet.new_player_direction()
+	player_direction_message.set_direction(direction)
+	player_direction_message.set_sequence(_input_sequence)
+	player_direction_message.set_client_time(client_time)
+	WS.send(packet)
+
+# Takes the server's word for where the player is, having applied the inputs up to
+# last_input_sequence, then plays the inputs it hasn't seen yet on top of that
+func reconcile(x: float, y: float, server_direction: float, last_input_sequence: int) -> void:
+	while not _pending_inputs.is_empty() and not _is_later_input(_pending_inputs[0].sequence, last_input_sequence):
+		_pending_inputs.pop_front()
+	
+	var predicted := Vector2(x, y)
+	var now := Time.get_ticks_msec() / 1000.0
+	var previous_heading := Vector2.from_angle(server_direction)
+	for input in _pending_inputs:
+		var heading := Vector2.from_angle(input.direction)
+		predicted += (heading - previous_heading) * speed * (now - input.time)
+		previous_heading = heading
+	server_position = predicted
+
+# Same as the server: an input is later than the 2^31 inputs before it
+func _is_later_input(sequence: int, last: int) -> bool:
+	var diff := (sequence - last) & 0xFFFFFFFF
+	return diff != 0 and diff < 0x80000000
 
 func _update_zoom() -> void:
 	if is_node_ready():
@@ -98,6 +136,9 @@ func _update_zoom() -> void:
 	
 	_furthest_zoom_allowed = new_furthest_zoom_allowed
 	
+func follow() -> void:
+	_camera.make_current()
+
 func _draw() -> void:
 	draw_circle(Vector2.ZERO, _collision_shape.radius, color)
 	
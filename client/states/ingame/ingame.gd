@@ -10,9 +10,16 @@ const Spore := preload("res://objects/spore/spore.gd")
 @onready var _hiscores: Hiscores = $UI/MarginContainer/VBoxContainer/Hiscores
 @onready var _logout_button: Button = $UI/MarginContainer/VBoxContainer/HBoxContainer/LogoutButton
 @onready var _send_button: Button = $UI/MarginContainer/VBoxContainer/HBoxContainer/SendButton
+@onready var _death_screen: PanelContainer = $UI/DeathScreen
+@onready var _death_summary: Label = $UI/DeathScreen/VBoxContainer/Summary
+@onready var _respawn_button: Button = $UI/DeathScreen/VBoxContainer/HBoxContainer/RespawnButton
+@onready var _spectate_button: Button = $UI/DeathScreen/VBoxContainer/HBoxContainer/SpectateButton
+@onready var _menu_button: Button = $UI/DeathScreen/VBoxContainer/HBoxContainer/MenuButton
 
 var _players: Dictionary[int, Actor]
 var _spores: Dictionary[int, Spore]
+var _dead := false
+var _spectating := false
 
 func _ready() -> void:
 	WS.connection_closed.connect(_on_ws_connection_closed)
@@ -21,6 +28,9 @@ func _ready() -> void:
 	_logout_button.pressed.connect(_on_logout_button_pressed)
 	_send_button.pressed.connect(_on_send_button_pressed)
 	_line_edit.text_submitted.connect(_on_line_edit_text_submitted)
+	_respawn_button.pressed.connect(_on_respawn_button_pressed)
+	_spectate_button.pressed.connect(_on_spectate_button_pressed)
+	_menu_button.pressed.connect(_on_menu_button_pressed)
 	
 func _on_ws_connection_closed() -> void:
 	_log.warning("Connection closed !")
@@ -39,6 +49,12 @@ func _on_ws_packet_received(packet: packets.Packet) -> void:
 		_handle_on_spore_consumed_msg(sender_id, packet.get_spore_consumed())
 	elif packet.has_disconnect():
 		_handle_disconnect_msg(sender_id, packet.get_disconnect())
+	elif packet.has_player_consumed():
+		_handle_player_consumed_msg(sender_id, packet.get_player_consumed())
+	elif packet.has_death():
+		_handle_death_msg(sender_id, packet.get_death())
+	elif packet.has_spectate_target():
+		_handle_spectate_target_msg(sender_id, packet.get_spectate_target())
 
 func _handle_player_msg(sender_id: int, player_msg: packets.PlayerMessage)-> void:
 	var actor_id := player_msg.get_id()
@@ -55,7 +71,8 @@ func _handle_player_msg(sender_id: int, player_msg: packets.PlayerMessage)-> voi
 		_add_actor(actor_id, actor_name, x, y, radius, speed, color, is_player)
 	else:
 		var direction := player_msg.get_direction()
-		_update_actor(actor_id, actor_name, x, y, direction, radius, speed, is_player)
+		var last_input_sequence := player_msg.get_last_input_sequence()
+		_update_actor(actor_id, actor_name, x, y, direction, radius, speed, is_player, last_input_sequence)
 
 func _add_actor(actor_id: int, actor_name: String, x: float, y: float, radius: float, speed: float, color: Color, is_player: bool) -> void:
 	var actor := Actor.instantiate(actor_id, actor_name, x, y, radius, speed, color, is_player)
@@ -67,17 +84,23 @@ func _add_actor(actor_id: int, actor_name: String, x: float, y: float, radius: f
 	if is_player:
 		actor.area_entered.connect(_on_player_area_entered)
 	
-func _update_actor(actor_id: int, actor_name: String, x: float, y: float, direction:float, radius: float, speed: float, is_player: bool) -> void:
+func _update_actor(actor_id: int, actor_name: String, x: float, y: float, direction:float, radius: float, speed: float, is_player: bool, last_input_sequence: int) -> void:
 	var actor := _players[actor_id]
 	
 	_set_actor_mass(actor, _rad_to_mass(radius))
+	actor.speed = speed
+	
+	if is_player:
+		actor.velocity = actor.velocity.normalized() * speed
+		# Our own player is ahead of the server by whatever inputs it hasn't applied yet
+		actor.reconcile(x, y, direction, last_input_sequence)
+		return
 	
 	if actor.position.distance_squared_to(Vector2(x, y)) > 100:
 		actor.server_position.x = x
 		actor.server_position.y = y
 	
-	if not is_player:
-		actor.velocity = speed * Vector2.from_angle(direction)
+	actor.velocity = speed * Vector2.from_angle(direction)
 	
 func _handle_spore_msg(sender_id: int, spore_msg: packets.SporeMessage) -> void:
 	var spore_id = spore_msg.get_id()
@@ -122,6 +145,32 @@ func _handle_disconnect_msg(sender_id: int, disconnect_msg: packets.DisconnectMe
 		_log.info("%s disconnected because %s" % [actor.actor_name, reason])
 		_remove_actor(actor)
 
+func _handle_player_consumed_msg(sender_id: int, player_consumed_msg: packets.PlayerConsumedMessage) -> void:
+	var actor_id := player_consumed_msg.get_player_id()
+	if actor_id in _players:
+		_remove_actor(_players[actor_id])
+
+func _handle_death_msg(sender_id: int, death_msg: packets.DeathMessage) -> void:
+	_dead = true
+	if GameManager.client_id in _players:
+		_remove_actor(_players[GameManager.client_id])
+	
+	var killer_name := death_msg.get_killer_name()
+	if killer_name.is_empty():
+		killer_name = "something"
+	
+	_death_summary.text = "You were eaten by %s\nFinal mass: %d\nTime alive: %ds" % [
+		killer_name,
+		death_msg.get_final_mass(),
+		roundi(death_msg.get_time_alive()),
+	]
+	_death_screen.show()
+
+func _handle_spectate_target_msg(sender_id: int, spectate_target_msg: packets.SpectateTargetMessage) -> void:
+	var target_id := spectate_target_msg.get_player_id()
+	if target_id in _players:
+		_players[target_id].follow()
+
 func _set_actor_mass(actor: Actor, new_mass: float) -> void:
 	actor.radius = sqrt(new_mass / PI)
 	_hiscores.set_hiscore(actor.actor_name, roundi(new_mass))
@@ -182,6 +231,10 @@ func _handle_chat_msg(sender_id: int, chat_msg: packets.ChatMessage) -> void:
 		_log.chat(actor.actor_name, chat_msg.get_msg())
 
 func _on_logout_button_pressed() -> void:
+	if _dead:
+		_on_menu_button_pressed()
+		return
+	
 	var packet := packets.Packet.new()
 	var disconnect_msg := packet.new_disconnect()
 	disconnect_msg.set_reason("they logged out")
@@ -189,6 +242,32 @@ func _on_logout_button_pressed() -> void:
 	
 	GameManager.set_state(GameManager.State.CONNECTED)
 
+func _on_respawn_button_pressed() -> void:
+	var packet := packets.Packet.new()
+	packet.new_respawn_request()
+	WS.send(packet)
+	
+	_dead = false
+	_death_screen.hide()
+
+func _on_spectate_button_pressed() -> void:
+	var packet := packets.Packet.new()
+	packet.new_spectate_request()
+	WS.send(packet)
+	
+	_spectating = true
+	_death_screen.hide()
+
+func _on_menu_button_pressed() -> void:
+	var packet := packets.Packet.new()
+	if _spectating:
+		packet.new_finished_spectating()
+	else:
+		packet.new_return_to_menu()
+	WS.send(packet)
+	
+	GameManager.set_state(GameManager.State.CONNECTED)
+
 func _on_send_button_pressed() -> void:
 	_on_line_edit_text_submitted(_line_edit.text)
 
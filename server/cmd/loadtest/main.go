@@ -0,0 +1,403 @@
+// Opens a swarm of WebSocket clients against a running server, each logging in and moving
+// around like a player, and reports how quickly and reliably the server keeps up.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"math"
+	"math/rand/v2"
+	"os"
+	"os/signal"
+	"server/pkg/packets"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"google.golang.org/protobuf/proto"
+)
+
+var (
+	url               = flag.String("url", "ws://localhost:8080/ws", "WebSocket URL of the server")
+	clientCount       = flag.Int("clients", 50, "Number of clients to connect")
+	rampUp            = flag.Duration("ramp", 10*time.Second, "How long to spread connecting the clients over")
+	duration          = flag.Duration("duration", time.Minute, "How long to run for once every client has connected")
+	directionInterval = flag.Duration("direction-interval", 250*time.Millisecond, "How often each client changes direction")
+	reportInterval    = flag.Duration("report-interval", 5*time.Second, "How often to report progress")
+	password          = flag.String("password", "loadtest", "Password for the loadtest_<i> accounts")
+	responseTimeout   = flag.Duration("timeout", 10*time.Second, "How long to wait for the server to respond to a login")
+)
+
+// Everything the swarm measures, shared by every client
+type stats struct {
+	mux       sync.Mutex
+	latencies []time.Duration
+	// Every latency taken so far, for the final report
+	allLatencies []time.Duration
+
+	connected    atomic.Int64
+	failed       atomic.Int64
+	disconnected atomic.Int64
+
+	directionsSent atomic.Int64
+	// Directions the server never echoed back
+	unanswered atomic.Int64
+	// Inbound messages that couldn't be read
+	malformed atomic.Int64
+
+	sporesClaimed atomic.Int64
+	sent          atomic.Int64
+	received      atomic.Int64
+	bytesReceived atomic.Int64
+}
+
+func (s *stats) addLatency(latency time.Duration) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	s.latencies = append(s.latencies, latency)
+}
+
+// Returns the latencies recorded since the last call, sorted
+func (s *stats) takeLatencies() []time.Duration {
+	s.mux.Lock()
+	latencies := s.latencies
+	s.latencies = nil
+	s.allLatencies = append(s.allLatencies, latencies...)
+	s.mux.Unlock()
+
+	slices.Sort(latencies)
+	return latencies
+}
+
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	return sorted[min(len(sorted)-1, int(float64(len(sorted))*p))]
+}
+
+type swarmClient struct {
+	index int
+	conn  *websocket.Conn
+	stats *stats
+
+	writeMux sync.Mutex
+
+	// Everything below is only touched by the reader after logging in
+	id               uint64
+	x, y, radius     float64
+	spores           map[uint64]*packets.SporeMessage
+	pendingMux       sync.Mutex
+	pendingDirection map[float64]time.Time
+}
+
+func (c *swarmClient) username() string {
+	return fmt.Sprintf("loadtest_%d", c.index)
+}
+
+func (c *swarmClient) send(message packets.Msg) error {
+	data, err := proto.Marshal(&packets.Packet{Msg: message})
+	if err != nil {
+		return err
+	}
+
+	c.writeMux.Lock()
+	defer c.writeMux.Unlock()
+
+	c.stats.sent.Add(1)
+	return c.conn.WriteMessage(websocket.BinaryMessage, data)
+}
+
+func (c *swarmClient) read() (*packets.Packet, error) {
+	_, data, err := c.conn.ReadMessage()
+	if err != nil {
+		return nil, err
+	}
+	c.stats.received.Add(1)
+	c.stats.bytesReceived.Add(int64(len(data)))
+
+	// The server ends every message with a newline
+	if len(data) > 0 && data[len(data)-1] == '\n' {
+		data = data[:len(data)-1]
+	}
+
+	packet := &packets.Packet{}
+	if err := proto.Unmarshal(data, packet); err != nil {
+		c.stats.malformed.Add(1)
+		return nil, nil
+	}
+	return packet, nil
+}
+
+// Reads until an ok or deny response, returning the reason if it was a deny
+func (c *swarmClient) awaitResponse() (string, error) {
+	c.conn.SetReadDeadline(time.Now().Add(*responseTimeout))
+	defer c.conn.SetReadDeadline(time.Time{})
+
+	for {
+		packet, err := c.read()
+		if err != nil {
+			return "", err
+		}
+		switch message := packet.GetMsg().(type) {
+		case *packets.Packet_OkResponse:
+			return "", nil
+		case *packets.Packet_DenyResponse:
+			return message.DenyResponse.Reason, nil
+		case *packets.Packet_Id:
+			c.id = message.Id.Id
+		}
+	}
+}
+
+// Registers the client's account, which may well exist from an earlier run, then logs in
+func (c *swarmClient) login() error {
+	err := c.send(&packets.Packet_RegisterRequest{
+		RegisterRequest: &packets.RegisterRequestMessage{Username: c.username(), Password: *password, Color: rand.Int32()},
+	})
+	if err != nil {
+		return err
+	}
+	if _, err := c.awaitResponse(); err != nil {
+		return err
+	}
+
+	err = c.send(&packets.Packet_LoginRequest{
+		LoginRequest: &packets.LoginRequestMessage{Username: c.username(), Password: *password},
+	})
+	if err != nil {
+		return err
+	}
+	reason, err := c.awaitResponse()
+	if err != nil {
+		return err
+	}
+	if reason != "" {
+		return fmt.Errorf("login denied: %s", reason)
+	}
+	return nil
+}
+
+func (c *swarmClient) readLoop() {
+	for {
+		packet, err := c.read()
+		if err != nil {
+			return
+		}
+		if packet == nil {
+			continue
+		}
+
+		switch message := packet.Msg.(type) {
+		case *packets.Packet_PlayerDirection:
+			if packet.SenderId == c.id {
+				c.directionEchoed(message.PlayerDirection.Direction)
+			}
+		case *packets.Packet_Player:
+			if packet.SenderId == c.id {
+				c.x, c.y, c.radius = message.Player.X, message.Player.Y, message.Player.Radius
+				c.claimSpores()
+			}
+		case *packets.Packet_Spore:
+			c.spores[message.Spore.Id] = message.Spore
+		case *packets.Packet_SporeBatch:
+			for _, spore := range message.SporeBatch.Spores {
+				c.spores[spore.Id] = spore
+			}
+		case *packets.Packet_SporeConsumed:
+			delete(c.spores, message.SporeConsumed.SporeId)
+		case *packets.Packet_Disconnect:
+			if packet.SenderId == c.id {
+				log.Printf("%s was disconnected: %s", c.username(), message.Disconnect.Reason)
+			}
+		}
+	}
+}
+
+// Claims every spore the client is on top of, like a real client would
+func (c *swarmClient) claimSpores() {
+	for sporeId, spore := range c.spores {
+		if math.Hypot(spore.X-c.x, spore.Y-c.y) > c.radius {
+			continue
+		}
+		delete(c.spores, sporeId)
+
+		err := c.send(&packets.Packet_SporeConsumed{
+			SporeConsumed: &packets.SporeConsumedMessage{SporeId: sporeId},
+		})
+		if err != nil {
+			return
+		}
+		c.stats.sporesClaimed.Add(1)
+	}
+}
+
+func (c *swarmClient) sendDirection() error {
+	direction := rand.Float64()*2*math.Pi - math.Pi
+
+	c.pendingMux.Lock()
+	c.pendingDirection[direction] = time.Now()
+	c.pendingMux.Unlock()
+
+	c.stats.directionsSent.Add(1)
+	return c.send(&packets.Packet_PlayerDirection{
+		PlayerDirection: &packets.PlayerDirectionMessage{Direction: direction},
+	})
+}
+
+func (c *swarmClient) directionEchoed(direction float64) {
+	c.pendingMux.Lock()
+	sentAt, exists := c.pendingDirection[direction]
+	delete(c.pendingDirection, direction)
+	c.pendingMux.Unlock()
+
+	if exists {
+		c.stats.addLatency(time.Since(sentAt))
+	}
+}
+
+func (c *swarmClient) run(ctx context.Context) {
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, *url, nil)
+	if err != nil {
+		log.Printf("%s couldn't connect: %v", c.username(), err)
+		c.stats.failed.Add(1)
+		return
+	}
+	c.conn = conn
+	defer conn.Close()
+
+	if err := c.login(); err != nil {
+		log.Printf("%s couldn't log in: %v", c.username(), err)
+		c.stats.failed.Add(1)
+		return
+	}
+	c.stats.connected.Add(1)
+
+	readDone := make(chan struct{})
+	go func() {
+		c.readLoop()
+		close(readDone)
+	}()
+
+	ticker := time.NewTicker(*directionInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := c.sendDirection(); err != nil {
+				c.stats.disconnected.Add(1)
+				return
+			}
+		case <-readDone:
+			c.stats.disconnected.Add(1)
+			return
+		case <-ctx.Done():
+			c.writeMux.Lock()
+			conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+			c.writeMux.Unlock()
+
+			// Give the last echoes a moment to arrive before counting what's missing
+			select {
+			case <-readDone:
+			case <-time.After(time.Second):
+			}
+
+			c.pendingMux.Lock()
+			c.stats.unanswered.Add(int64(len(c.pendingDirection)))
+			c.pendingMux.Unlock()
+			return
+		}
+	}
+}
+
+func report(stats *stats, elapsed time.Duration, final bool) {
+	latencies := stats.takeLatencies()
+	if final {
+		latencies = stats.allLatencies
+		slices.Sort(latencies)
+	}
+	seconds := elapsed.Seconds()
+	received := stats.received.Swap(0)
+	bytesReceived := stats.bytesReceived.Swap(0)
+	sent := stats.sent.Swap(0)
+
+	label := "Progress"
+	if final {
+		label = "Final"
+	}
+
+	log.Printf("%s: %d connected, %d failed, %d disconnected", label, stats.connected.Load(), stats.failed.Load(), stats.disconnected.Load())
+	log.Printf("  direction echo latency over %d samples: p50 %v, p90 %v, p99 %v, max %v",
+		len(latencies), percentile(latencies, 0.5), percentile(latencies, 0.9), percentile(latencies, 0.99), percentile(latencies, 1))
+	log.Printf("  throughput: %.0f msg/s (%.1f KiB/s) in, %.0f msg/s out",
+		float64(received)/seconds, float64(bytesReceived)/1024/seconds, float64(sent)/seconds)
+
+	if final {
+		log.Printf("  %d directions sent, %d never echoed, %d malformed messages, %d spores claimed",
+			stats.directionsSent.Load(), stats.unanswered.Load(), stats.malformed.Load(), stats.sporesClaimed.Load())
+	}
+}
+
+func main() {
+	flag.Parse()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	runCtx, cancel := context.WithTimeout(ctx, *rampUp+*duration)
+	defer cancel()
+
+	stats := &stats{}
+	var wg sync.WaitGroup
+
+	log.Printf("Connecting %d clients to %s over %v", *clientCount, *url, *rampUp)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		interval := *rampUp / time.Duration(max(1, *clientCount))
+		for i := range *clientCount {
+			client := &swarmClient{
+				index:            i,
+				stats:            stats,
+				spores:           make(map[uint64]*packets.SporeMessage),
+				pendingDirection: make(map[float64]time.Time),
+			}
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				client.run(runCtx)
+			}()
+
+			select {
+			case <-time.After(interval):
+			case <-runCtx.Done():
+				return
+			}
+		}
+	}()
+
+	ticker := time.NewTicker(*reportInterval)
+	defer ticker.Stop()
+
+	// Everything but the final totals is reported per interval
+	lastReport := time.Now()
+	for runCtx.Err() == nil {
+		select {
+		case <-ticker.C:
+			report(stats, time.Since(lastReport), false)
+			lastReport = time.Now()
+		case <-runCtx.Done():
+		}
+	}
+
+	wg.Wait()
+	report(stats, time.Since(lastReport), true)
+}
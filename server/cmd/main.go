@@ -1,16 +1,27 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"log"
+	"log/slog"
+	"net"
 	"net/http"
 	"os"
+	"os/signal"
 	"path/filepath"
 	"server/internal/server"
+	"server/internal/server/admin"
 	"server/internal/server/clients"
+	"server/internal/server/metrics"
+	"server/internal/server/replay"
+	"server/internal/server/sim"
 	"strconv"
 	"strings"
+	"syscall"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -21,15 +32,37 @@ const (
 )
 
 type config struct {
-	Port     int
-	DataPath string
-	CertPath string
-	KeyPath  string
+	Port int
+	// Ports for raw TCP clients and their datagrams, zero meaning the transport is off
+	TCPPort       int
+	DatagramPort  int
+	DataPath      string
+	CertPath      string
+	KeyPath       string
+	BotCount      int
+	BotDifficulty string
+	// How long to wait for clients to leave (and best scores to be saved) on shutdown
+	ShutdownTimeout time.Duration
+	WebSocket       clients.WebSocketConfig
+	TCP             clients.TCPConfig
+	Limits          server.ConnectionLimits
+	AntiCheat       server.AntiCheatConfig
+	// Bearer token for the admin API, which is off without one
+	AdminToken string
+	// How long each replay file covers, zero meaning games aren't recorded
+	ReplayInterval time.Duration
+	// Seeds the game's randomness so spawns and drops come out the same each run, zero meaning a random seed
+	Seed uint64
 }
 
 var (
 	defaultConfig = &config{
-		Port: 8080,
+		Port:            8080,
+		BotDifficulty:   "medium",
+		ShutdownTimeout: 10 * time.Second,
+		WebSocket:       clients.DefaultWebSocketConfig,
+		TCP:             clients.DefaultTCPConfig,
+		AntiCheat:       server.DefaultAntiCheatConfig,
 	}
 	configPath = flag.String("config", ".env", "Path to the config file")
 )
@@ -39,28 +72,139 @@ func loadConfig() *config {
 	cfg.DataPath = os.Getenv("DATA_PATH")
 	cfg.CertPath = os.Getenv("CERT_PATH")
 	cfg.KeyPath = os.Getenv("KEY_PATH")
+	cfg.Port = intFromEnv("PORT", cfg.Port)
+	cfg.TCPPort = intFromEnv("TCP_PORT", cfg.TCPPort)
+	cfg.DatagramPort = intFromEnv("UDP_PORT", cfg.DatagramPort)
+	cfg.BotCount = intFromEnv("BOT_COUNT", cfg.BotCount)
+	cfg.AdminToken = os.Getenv("ADMIN_TOKEN")
+	cfg.ReplayInterval = durationFromEnv("REPLAY_INTERVAL", cfg.ReplayInterval)
+	cfg.Seed = uint64FromEnv("SIM_SEED", cfg.Seed)
 
-	port, err := strconv.Atoi(os.Getenv("PORT"))
-	if err != nil {
-		log.Printf("Errors parsing PORT, using %d", cfg.Port)
-		return cfg
+	if difficulty := os.Getenv("BOT_DIFFICULTY"); difficulty != "" {
+		cfg.BotDifficulty = difficulty
+	}
+
+	cfg.ShutdownTimeout = durationFromEnv("SHUTDOWN_TIMEOUT", cfg.ShutdownTimeout)
+	cfg.WebSocket.PingInterval = durationFromEnv("WS_PING_INTERVAL", cfg.WebSocket.PingInterval)
+	cfg.WebSocket.PongWait = durationFromEnv("WS_PONG_WAIT", cfg.WebSocket.PongWait)
+	cfg.WebSocket.WriteWait = durationFromEnv("WS_WRITE_WAIT", cfg.WebSocket.WriteWait)
+	cfg.WebSocket.IdleTimeout = durationFromEnv("WS_IDLE_TIMEOUT", cfg.WebSocket.IdleTimeout)
+	cfg.WebSocket.MaxMessageSize = int64(intFromEnv("WS_MAX_MESSAGE_SIZE", int(cfg.WebSocket.MaxMessageSize)))
+
+	if origins := os.Getenv("WS_ALLOWED_ORIGINS"); origins != "" {
+		for _, origin := range strings.Split(origins, ",") {
+			cfg.WebSocket.AllowedOrigins = append(cfg.WebSocket.AllowedOrigins, strings.TrimSpace(origin))
+		}
 	}
-	cfg.Port = port
+
+	cfg.TCP.WriteWait = durationFromEnv("TCP_WRITE_WAIT", cfg.TCP.WriteWait)
+	cfg.TCP.IdleTimeout = durationFromEnv("TCP_IDLE_TIMEOUT", cfg.TCP.IdleTimeout)
+	cfg.TCP.KeepAlive = durationFromEnv("TCP_KEEP_ALIVE", cfg.TCP.KeepAlive)
+	cfg.TCP.MaxMessageSize = intFromEnv("TCP_MAX_MESSAGE_SIZE", cfg.TCP.MaxMessageSize)
+
+	cfg.Limits.MaxClients = intFromEnv("MAX_CLIENTS", cfg.Limits.MaxClients)
+	cfg.Limits.MaxClientsPerIP = intFromEnv("MAX_CLIENTS_PER_IP", cfg.Limits.MaxClientsPerIP)
+
+	cfg.AntiCheat.KickThreshold = floatFromEnv("ANTICHEAT_KICK_THRESHOLD", cfg.AntiCheat.KickThreshold)
+	cfg.AntiCheat.HalfLife = durationFromEnv("ANTICHEAT_HALF_LIFE", cfg.AntiCheat.HalfLife)
 
 	return cfg
 }
 
+// Can be changed while the server is running, by editing LOG_LEVEL in the config file and sending SIGHUP
+var logLevel = new(slog.LevelVar)
+
+// Logs as text, or JSON if LOG_FORMAT says so, at the level in LOG_LEVEL (debug, info, warn or error)
+func setupLogging() {
+	logLevel.Set(levelFromEnv(slog.LevelInfo))
+	options := &slog.HandlerOptions{Level: logLevel}
+
+	var handler slog.Handler
+	if strings.EqualFold(os.Getenv("LOG_FORMAT"), "json") {
+		handler = slog.NewJSONHandler(os.Stderr, options)
+	} else {
+		handler = slog.NewTextHandler(os.Stderr, options)
+	}
+	slog.SetDefault(slog.New(handler))
+}
+
+func levelFromEnv(fallback slog.Level) slog.Level {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(os.Getenv("LOG_LEVEL"))); err != nil {
+		return fallback
+	}
+	return level
+}
+
+func reloadLogLevelOnHangup(ctx context.Context) {
+	hangups := make(chan os.Signal, 1)
+	signal.Notify(hangups, syscall.SIGHUP)
+	defer signal.Stop(hangups)
+
+	for {
+		select {
+		case <-hangups:
+		case <-ctx.Done():
+			return
+		}
+
+		if err := godotenv.Overload(*configPath); err != nil {
+			slog.Error("Error reloading config file", "path", *configPath, "error", err)
+			continue
+		}
+		logLevel.Set(levelFromEnv(logLevel.Level()))
+		slog.Info("Reloaded log level", "level", logLevel.Level())
+	}
+}
+
+func durationFromEnv(key string, fallback time.Duration) time.Duration {
+	value, err := time.ParseDuration(os.Getenv(key))
+	if err != nil {
+		slog.Debug("Error parsing env var, using fallback", "key", key, "fallback", fallback)
+		return fallback
+	}
+	return value
+}
+
+func intFromEnv(key string, fallback int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil {
+		slog.Debug("Error parsing env var, using fallback", "key", key, "fallback", fallback)
+		return fallback
+	}
+	return value
+}
+
+// Seeds use the whole 64 bits, which don't all fit in an int
+func uint64FromEnv(key string, fallback uint64) uint64 {
+	value, err := strconv.ParseUint(os.Getenv(key), 10, 64)
+	if err != nil {
+		slog.Debug("Error parsing env var, using fallback", "key", key, "fallback", fallback)
+		return fallback
+	}
+	return value
+}
+
+func floatFromEnv(key string, fallback float64) float64 {
+	value, err := strconv.ParseFloat(os.Getenv(key), 64)
+	if err != nil {
+		slog.Debug("Error parsing env var, using fallback", "key", key, "fallback", fallback)
+		return fallback
+	}
+	return value
+}
+
 func coalescePaths(fallbacks ...string) string {
 	for i, path := range fallbacks {
 		if _, err := os.Stat(path); os.IsNotExist(err) {
-			message := fmt.Sprintf("File/folder not found at %s", path)
+			message := fmt.Sprintf("File/folder not found at %q", path)
 			if i < len(fallbacks)-1 {
-				log.Printf("%s - going to try %s", message, fallbacks[i+1])
+				slog.Info(message, "next", fallbacks[i+1])
 			} else {
-				log.Printf("%s - no more fallbacks to try", message)
+				slog.Warn(message + ", no more fallbacks to try")
 			}
 		} else {
-			log.Printf("File/folder found at %s", path)
+			slog.Info("File/folder found", "path", path)
 			return path
 		}
 	}
@@ -85,10 +229,11 @@ func resolveLiveCertsPath(certPath string) string {
 func main() {
 	flag.Parse()
 	err := godotenv.Load(*configPath)
+	setupLogging()
 	cfg := defaultConfig
 
 	if err != nil {
-		log.Printf("Error loading config file, defaulting to %+v\n", defaultConfig)
+		slog.Warn("Error loading config file, using defaults", "path", *configPath, "error", err, "config", fmt.Sprintf("%+v", defaultConfig))
 	} else {
 		cfg = loadConfig()
 	}
@@ -96,26 +241,123 @@ func main() {
 	cfg.DataPath = coalescePaths(cfg.DataPath, dockerMontedDataDir, "./data", ".")
 
 	hub := server.NewHub(cfg.DataPath)
+	hub.Limits = cfg.Limits
+	hub.AntiCheat = cfg.AntiCheat
+	if cfg.Seed != 0 {
+		hub.Sim = sim.New(sim.SystemClock{}, cfg.Seed)
+		slog.Info("Seeding the game", "seed", cfg.Seed)
+	}
+
+	replays, err := replay.NewLibrary(filepath.Join(cfg.DataPath, "replays"))
+	if err != nil {
+		slog.Warn("Error opening replay library, replays are off", "error", err)
+	} else {
+		hub.Replays = replays
+		if cfg.ReplayInterval > 0 {
+			if err := hub.RecordReplays(cfg.ReplayInterval); err != nil {
+				log.Fatalf("Failed to start recording replays: %v", err)
+			}
+			slog.Info("Recording replays", "interval", cfg.ReplayInterval)
+		}
+	}
+	hub.RegisterMetrics(metrics.Default)
+
+	http.Handle("/metrics", metrics.Default)
+
+	if cfg.AdminToken != "" {
+		adminAPI, err := admin.NewAPI(hub, cfg.AdminToken)
+		if err != nil {
+			log.Fatalf("Failed to start admin API: %v", err)
+		}
+		http.Handle("/admin/", adminAPI)
+		slog.Info("Serving admin API", "path", "/admin/")
+	}
 
+	newWebSocketClient := clients.NewWebSocketClientFactory(cfg.WebSocket)
 	http.HandleFunc("/ws", func(w http.ResponseWriter, r *http.Request) {
-		hub.Serve(clients.NewWebSocketClient, w, r)
+		hub.Serve(newWebSocketClient, w, r)
 	})
 
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
 	go hub.Run()
+	go reloadLogLevelOnHangup(ctx)
+
+	if cfg.BotCount > 0 {
+		difficulty, err := clients.ParseBotDifficulty(cfg.BotDifficulty)
+		if err != nil {
+			slog.Warn("Invalid bot difficulty", "error", err, "using", clients.BotMedium.Name)
+			difficulty = clients.BotMedium
+		}
+		go clients.NewBotManager(hub, difficulty, cfg.BotCount).Run(ctx, time.Second)
+	}
+
 	addr := fmt.Sprintf(":%d", cfg.Port)
-	log.Printf("Starting server on %s", addr)
+	slog.Info("Starting server", "addr", addr)
 
 	cfg.CertPath = resolveLiveCertsPath(cfg.CertPath)
 	cfg.KeyPath = resolveLiveCertsPath(cfg.KeyPath)
 
-	log.Printf("Using cert at %s and key at %s", cfg.CertPath, cfg.KeyPath)
-	err = http.ListenAndServeTLS(addr, cfg.CertPath, cfg.KeyPath, nil)
+	slog.Info("Using TLS certificate", "cert_path", cfg.CertPath, "key_path", cfg.KeyPath)
+	httpServer := &http.Server{Addr: addr}
 
-	if err != nil {
-		log.Printf("No certificate found (%v), starting server without TLS", err)
-		err = http.ListenAndServe(addr, nil)
-		if err != nil {
+	go func() {
+		err := httpServer.ListenAndServeTLS(cfg.CertPath, cfg.KeyPath)
+
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			slog.Warn("No certificate found, starting server without TLS", "error", err)
+			err = httpServer.ListenAndServe()
+		}
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
 			log.Fatalf("Failed to start server: %v", err)
 		}
+	}()
+
+	var datagrams *clients.DatagramServer
+	if cfg.DatagramPort > 0 {
+		datagrams, err = clients.ListenDatagrams(fmt.Sprintf(":%d", cfg.DatagramPort))
+		if err != nil {
+			log.Fatalf("Failed to listen for datagrams: %v", err)
+		}
+		slog.Info("Listening for datagrams", "port", cfg.DatagramPort)
+		defer datagrams.Close()
+
+		go func() {
+			if err := datagrams.Serve(); err != nil {
+				slog.Error("Error reading datagrams", "error", err)
+			}
+		}()
+	}
+
+	if cfg.TCPPort > 0 {
+		listener, err := net.Listen("tcp", fmt.Sprintf(":%d", cfg.TCPPort))
+		if err != nil {
+			log.Fatalf("Failed to listen for TCP clients: %v", err)
+		}
+		slog.Info("Listening for TCP clients", "port", cfg.TCPPort)
+		defer listener.Close()
+
+		newTCPClient := clients.NewTCPClientFactory(cfg.TCP, datagrams)
+		go func() {
+			if err := hub.ServeListener(newTCPClient, listener); err != nil {
+				slog.Error("Error accepting TCP clients", "error", err)
+			}
+		}()
+	}
+
+	<-ctx.Done()
+	stop()
+	slog.Info("Received shutdown signal, giving clients time to leave", "timeout", cfg.ShutdownTimeout)
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	defer cancel()
+
+	if err := httpServer.Shutdown(shutdownCtx); err != nil {
+		slog.Error("Error stopping HTTP server", "error", err)
+	}
+	if err := hub.Shutdown(shutdownCtx, "Server is shutting down"); err != nil {
+		slog.Error("Error shutting down hub", "error", err)
 	}
+	slog.Info("Shutdown complete")
 }
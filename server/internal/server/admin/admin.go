@@ -0,0 +1,325 @@
+// Package admin serves an HTTP API for operators to see who's connected and keep the game in
+// order while the server is running. Every request needs the admin token as a bearer token.
+package admin
+
+import (
+	"cmp"
+	"context"
+	"crypto/subtle"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"server/internal/server"
+	"server/internal/server/db"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// How long to wait for an online player's client to reset its best score before giving up
+const clientResetTimeout = 5 * time.Second
+
+type API struct {
+	hub    *server.Hub
+	token  string
+	dbTx   *server.DbTx
+	mux    *http.ServeMux
+	logger *slog.Logger
+}
+
+// Serves the API under /admin/. The token can't be empty, as that would let anyone in.
+func NewAPI(hub *server.Hub, token string) (*API, error) {
+	if token == "" {
+		return nil, errors.New("admin token is empty")
+	}
+
+	a := &API{
+		hub:    hub,
+		token:  token,
+		dbTx:   hub.NewDbTx(),
+		mux:    http.NewServeMux(),
+		logger: slog.Default().With("component", "admin"),
+	}
+
+	a.mux.HandleFunc("GET /admin/clients", a.handleListClients)
+	a.mux.HandleFunc("POST /admin/clients/{id}/kick", a.handleKick)
+	a.mux.HandleFunc("POST /admin/clients/{id}/ban", a.handleBanClient)
+	a.mux.HandleFunc("GET /admin/bans", a.handleListBans)
+	a.mux.HandleFunc("POST /admin/bans/users", a.handleBanUser)
+	a.mux.HandleFunc("POST /admin/bans/ips", a.handleBanIP)
+	a.mux.HandleFunc("DELETE /admin/bans/users/{id}", a.handleDeleteUserBan)
+	a.mux.HandleFunc("DELETE /admin/bans/ips/{id}", a.handleDeleteIpBan)
+	a.mux.HandleFunc("POST /admin/announce", a.handleAnnounce)
+	a.mux.HandleFunc("GET /admin/settings", a.handleGetSettings)
+	a.mux.HandleFunc("PATCH /admin/settings", a.handleUpdateSettings)
+	a.mux.HandleFunc("POST /admin/players/{name}/reset-best-score", a.handleResetBestScore)
+	a.mux.HandleFunc("GET /admin/suspects", a.handleListSuspects)
+	a.mux.HandleFunc("GET /admin/replays", a.handleListReplays)
+
+	return a, nil
+}
+
+func (a *API) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	token, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !found || subtle.ConstantTimeCompare([]byte(token), []byte(a.token)) != 1 {
+		a.logger.Warn("Rejecting unauthorized request", "remote_addr", r.RemoteAddr, "method", r.Method, "path", r.URL.Path)
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		writeError(w, http.StatusUnauthorized, errors.New("missing or wrong admin token"))
+		return
+	}
+
+	a.logger.Info("Handling request", "remote_addr", r.RemoteAddr, "method", r.Method, "path", r.URL.Path)
+	a.mux.ServeHTTP(w, r)
+}
+
+type clientJSON struct {
+	Id       uint64  `json:"id"`
+	State    string  `json:"state"`
+	Username string  `json:"username,omitempty"`
+	RemoteIP string  `json:"remote_ip,omitempty"`
+	RTTMs    float64 `json:"rtt_ms"`
+}
+
+func (a *API) handleListClients(w http.ResponseWriter, r *http.Request) {
+	list := []clientJSON{}
+	a.hub.Clients.ForEach(func(clientId uint64, client server.ClientInterfacer) {
+		status := client.Status()
+		ip, _ := a.hub.RemoteIP(client)
+		list = append(list, clientJSON{
+			Id:       clientId,
+			State:    status.State,
+			Username: status.Username,
+			RemoteIP: ip,
+			RTTMs:    float64(client.RTT()) / float64(time.Millisecond),
+		})
+	})
+	slices.SortFunc(list, func(a, b clientJSON) int {
+		return cmp.Compare(a.Id, b.Id)
+	})
+
+	writeJSON(w, http.StatusOK, list)
+}
+
+type reasonJSON struct {
+	Reason string `json:"reason"`
+}
+
+func (a *API) handleKick(w http.ResponseWriter, r *http.Request) {
+	clientId, reason, err := clientAndReason(r, "Kicked by an operator")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if !a.hub.Kick(clientId, reason) {
+		writeError(w, http.StatusNotFound, fmt.Errorf("no client with id %d", clientId))
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func clientAndReason(r *http.Request, defaultReason string) (uint64, string, error) {
+	clientId, err := strconv.ParseUint(r.PathValue("id"), 10, 64)
+	if err != nil {
+		return 0, "", fmt.Errorf("invalid client id: %w", err)
+	}
+
+	body := reasonJSON{}
+	if err := decodeOptionalBody(r, &body); err != nil {
+		return 0, "", err
+	}
+	if body.Reason == "" {
+		body.Reason = defaultReason
+	}
+	return clientId, body.Reason, nil
+}
+
+// Leaves the value as it is if there's no body at all
+func decodeOptionalBody(r *http.Request, v any) error {
+	if r.ContentLength == 0 {
+		return nil
+	}
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		return fmt.Errorf("invalid body: %w", err)
+	}
+	return nil
+}
+
+type announcementJSON struct {
+	Message string `json:"message"`
+}
+
+func (a *API) handleAnnounce(w http.ResponseWriter, r *http.Request) {
+	body := announcementJSON{}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid body: %w", err))
+		return
+	}
+	if strings.TrimSpace(body.Message) == "" {
+		writeError(w, http.StatusBadRequest, errors.New("message is empty"))
+		return
+	}
+
+	a.hub.Announce(body.Message)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+type settingsJSON struct {
+	MaxSpores              int    `json:"max_spores"`
+	SporeReplenishInterval string `json:"spore_replenish_interval"`
+	SporeReplenishBatch    int    `json:"spore_replenish_batch"`
+}
+
+// Only the settings that are given are changed
+type settingsPatchJSON struct {
+	MaxSpores              *int    `json:"max_spores"`
+	SporeReplenishInterval *string `json:"spore_replenish_interval"`
+	SporeReplenishBatch    *int    `json:"spore_replenish_batch"`
+}
+
+func (a *API) handleGetSettings(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, toSettingsJSON(a.hub.Settings()))
+}
+
+func (a *API) handleUpdateSettings(w http.ResponseWriter, r *http.Request) {
+	patch := settingsPatchJSON{}
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&patch); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid body: %w", err))
+		return
+	}
+
+	settings := a.hub.Settings()
+	if patch.MaxSpores != nil {
+		settings.MaxSpores = *patch.MaxSpores
+	}
+	if patch.SporeReplenishInterval != nil {
+		interval, err := time.ParseDuration(*patch.SporeReplenishInterval)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("invalid spore replenish interval: %w", err))
+			return
+		}
+		settings.SporeReplenishInterval = interval
+	}
+	if patch.SporeReplenishBatch != nil {
+		settings.SporeReplenishBatch = *patch.SporeReplenishBatch
+	}
+
+	if err := a.hub.SetSettings(settings); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toSettingsJSON(settings))
+}
+
+func toSettingsJSON(settings server.GameSettings) settingsJSON {
+	return settingsJSON{
+		MaxSpores:              settings.MaxSpores,
+		SporeReplenishInterval: settings.SporeReplenishInterval.String(),
+		SporeReplenishBatch:    settings.SporeReplenishBatch,
+	}
+}
+
+// Lists the names of the recorded replays, oldest first, for clients to ask to watch
+func (a *API) handleListReplays(w http.ResponseWriter, r *http.Request) {
+	if a.hub.Replays == nil {
+		writeError(w, http.StatusNotFound, errors.New("replays are off"))
+		return
+	}
+
+	names, err := a.hub.Replays.List()
+	if err != nil {
+		a.logger.Error("Error listing replays", "error", err)
+		writeError(w, http.StatusInternalServerError, errors.New("error listing replays"))
+		return
+	}
+	if names == nil {
+		names = []string{}
+	}
+	writeJSON(w, http.StatusOK, names)
+}
+
+// States holding a logged in player, whose best score lives in memory as well as the database
+type bestScoreResetter interface {
+	ResetBestScore()
+}
+
+func (a *API) handleResetBestScore(w http.ResponseWriter, r *http.Request) {
+	player, err := a.dbTx.Queries.GetPlayerByName(r.Context(), r.PathValue("name"))
+	if errors.Is(err, sql.ErrNoRows) {
+		writeError(w, http.StatusNotFound, fmt.Errorf("no player named %q", r.PathValue("name")))
+		return
+	}
+	if err != nil {
+		a.logger.Error("Error getting player", "name", r.PathValue("name"), "error", err)
+		writeError(w, http.StatusInternalServerError, errors.New("error getting player"))
+		return
+	}
+
+	if err := a.resetBestScore(r.Context(), player); err != nil {
+		a.logger.Error("Error resetting best score", "player", player.Name, "error", err)
+		writeError(w, http.StatusInternalServerError, errors.New("error resetting best score"))
+		return
+	}
+	a.logger.Info("Reset best score", "player", player.Name, "was", player.BestScore)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// If the player is online, the reset happens on their client's event loop, so it can't be
+// undone by the client saving the best score it had in memory
+func (a *API) resetBestScore(ctx context.Context, player db.Player) error {
+	reset := func() error {
+		return a.dbTx.Queries.UpdatePlayerBestScore(ctx, db.UpdatePlayerBestScoreParams{
+			ID:        player.ID,
+			BestScore: 0,
+		})
+	}
+
+	var online []server.ClientInterfacer
+	a.hub.Clients.ForEach(func(_ uint64, client server.ClientInterfacer) {
+		if strings.EqualFold(client.Status().Username, player.Name) {
+			online = append(online, client)
+		}
+	})
+	if len(online) == 0 {
+		return reset()
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, clientResetTimeout)
+	defer cancel()
+
+	for _, client := range online {
+		result := make(chan error, 1)
+		client.Post(func() {
+			if state, ok := client.State().(bestScoreResetter); ok {
+				state.ResetBestScore()
+			}
+			result <- reset()
+		})
+
+		select {
+		case err := <-result:
+			if err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
@@ -0,0 +1,254 @@
+package admin
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"server/internal/server"
+	"server/internal/server/db"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type banJSON struct {
+	Id       int64  `json:"id"`
+	Username string `json:"username,omitempty"`
+	Cidr     string `json:"cidr,omitempty"`
+	Reason   string `json:"reason"`
+	// Null for a ban that never ends
+	ExpiresAt *time.Time `json:"expires_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+type bansJSON struct {
+	Users []banJSON `json:"users"`
+	IPs   []banJSON `json:"ips"`
+}
+
+type banRequestJSON struct {
+	Username string `json:"username"`
+	// A single address or a CIDR range
+	IP     string `json:"ip"`
+	Reason string `json:"reason"`
+	// How long the ban lasts, like "72h", or forever if empty
+	Duration string `json:"duration"`
+}
+
+// Turns a duration from a request into when the ban ends, the zero time meaning never
+func (b banRequestJSON) expiresAt(now time.Time) (time.Time, error) {
+	if b.Duration == "" {
+		return time.Time{}, nil
+	}
+	duration, err := time.ParseDuration(b.Duration)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid duration: %w", err)
+	}
+	if duration <= 0 {
+		return time.Time{}, errors.New("duration must be positive")
+	}
+	return now.Add(duration), nil
+}
+
+func fromBanTime(t sql.NullInt64) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	converted := time.Unix(t.Int64, 0).UTC()
+	return &converted
+}
+
+func userBanJSON(ban db.UserBan, username string) banJSON {
+	return banJSON{
+		Id:        ban.ID,
+		Username:  username,
+		Reason:    ban.Reason,
+		ExpiresAt: fromBanTime(ban.ExpiresAt),
+		CreatedAt: time.Unix(ban.CreatedAt, 0).UTC(),
+	}
+}
+
+func ipBanJSON(ban db.IpBan) banJSON {
+	return banJSON{
+		Id:        ban.ID,
+		Cidr:      ban.Cidr,
+		Reason:    ban.Reason,
+		ExpiresAt: fromBanTime(ban.ExpiresAt),
+		CreatedAt: time.Unix(ban.CreatedAt, 0).UTC(),
+	}
+}
+
+func (a *API) handleListBans(w http.ResponseWriter, r *http.Request) {
+	now := server.BanTime(a.hub.Sim.Now())
+
+	userBans, err := a.dbTx.Queries.ListActiveUserBans(r.Context(), now)
+	if err != nil {
+		a.logger.Error("Error listing user bans", "error", err)
+		writeError(w, http.StatusInternalServerError, errors.New("error listing bans"))
+		return
+	}
+	ipBans, err := a.dbTx.Queries.ListActiveIpBans(r.Context(), now)
+	if err != nil {
+		a.logger.Error("Error listing IP bans", "error", err)
+		writeError(w, http.StatusInternalServerError, errors.New("error listing bans"))
+		return
+	}
+
+	list := bansJSON{Users: []banJSON{}, IPs: []banJSON{}}
+	for _, ban := range userBans {
+		list.Users = append(list.Users, banJSON{
+			Id:        ban.ID,
+			Username:  ban.Username,
+			Reason:    ban.Reason,
+			ExpiresAt: fromBanTime(ban.ExpiresAt),
+			CreatedAt: time.Unix(ban.CreatedAt, 0).UTC(),
+		})
+	}
+	for _, ban := range ipBans {
+		list.IPs = append(list.IPs, ipBanJSON(ban))
+	}
+	writeJSON(w, http.StatusOK, list)
+}
+
+// Bans whoever is logged in on the client, or the address it connected from if nobody is
+func (a *API) handleBanClient(w http.ResponseWriter, r *http.Request) {
+	clientId, err := strconv.ParseUint(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid client id: %w", err))
+		return
+	}
+	request := banRequestJSON{}
+	if err := decodeOptionalBody(r, &request); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	client, exists := a.hub.Clients.Get(clientId)
+	if !exists {
+		writeError(w, http.StatusNotFound, fmt.Errorf("no client with id %d", clientId))
+		return
+	}
+
+	if username := client.Status().Username; username != "" {
+		request.Username = username
+		a.banUser(w, r, request)
+		return
+	}
+
+	ip, connected := a.hub.RemoteIP(client)
+	if !connected {
+		writeError(w, http.StatusUnprocessableEntity, fmt.Errorf("client %d isn't logged in and has no address to ban", clientId))
+		return
+	}
+	request.IP = ip
+	a.banIP(w, r, request)
+}
+
+func (a *API) handleBanUser(w http.ResponseWriter, r *http.Request) {
+	request := banRequestJSON{}
+	if err := decodeOptionalBody(r, &request); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	a.banUser(w, r, request)
+}
+
+func (a *API) banUser(w http.ResponseWriter, r *http.Request, request banRequestJSON) {
+	expiresAt, err := request.expiresAt(a.hub.Sim.Now())
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	user, err := a.dbTx.Queries.GetUserByUsername(r.Context(), strings.ToLower(request.Username))
+	if errors.Is(err, sql.ErrNoRows) {
+		writeError(w, http.StatusNotFound, fmt.Errorf("no user named %q", request.Username))
+		return
+	}
+	if err != nil {
+		a.logger.Error("Error getting user", "username", request.Username, "error", err)
+		writeError(w, http.StatusInternalServerError, errors.New("error getting user"))
+		return
+	}
+
+	ban, err := a.hub.BanUser(r.Context(), user, reasonOrDefault(request.Reason), expiresAt)
+	if err != nil {
+		a.logger.Error("Error banning user", "username", user.Username, "error", err)
+		writeError(w, http.StatusInternalServerError, errors.New("error banning user"))
+		return
+	}
+	writeJSON(w, http.StatusCreated, userBanJSON(ban, user.Username))
+}
+
+func (a *API) handleBanIP(w http.ResponseWriter, r *http.Request) {
+	request := banRequestJSON{}
+	if err := decodeOptionalBody(r, &request); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	a.banIP(w, r, request)
+}
+
+func (a *API) banIP(w http.ResponseWriter, r *http.Request, request banRequestJSON) {
+	expiresAt, err := request.expiresAt(a.hub.Sim.Now())
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	prefix, err := server.ParseBanPrefix(request.IP)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if prefix.Bits() == 0 {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("refusing to ban every address (%s)", prefix))
+		return
+	}
+
+	ban, err := a.hub.BanIP(r.Context(), prefix, reasonOrDefault(request.Reason), expiresAt)
+	if err != nil {
+		a.logger.Error("Error banning address", "cidr", prefix, "error", err)
+		writeError(w, http.StatusInternalServerError, errors.New("error banning address"))
+		return
+	}
+	writeJSON(w, http.StatusCreated, ipBanJSON(ban))
+}
+
+func reasonOrDefault(reason string) string {
+	if reason == "" {
+		return "Banned by an operator"
+	}
+	return reason
+}
+
+func (a *API) handleDeleteUserBan(w http.ResponseWriter, r *http.Request) {
+	a.deleteBan(w, r, a.dbTx.Queries.DeleteUserBan)
+}
+
+func (a *API) handleDeleteIpBan(w http.ResponseWriter, r *http.Request) {
+	a.deleteBan(w, r, a.dbTx.Queries.DeleteIpBan)
+}
+
+func (a *API) deleteBan(w http.ResponseWriter, r *http.Request, deleteQuery func(ctx context.Context, id int64) (int64, error)) {
+	banId, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid ban id: %w", err))
+		return
+	}
+
+	deleted, err := deleteQuery(r.Context(), banId)
+	if err != nil {
+		a.logger.Error("Error lifting ban", "ban_id", banId, "error", err)
+		writeError(w, http.StatusInternalServerError, errors.New("error lifting ban"))
+		return
+	}
+	if deleted == 0 {
+		writeError(w, http.StatusNotFound, fmt.Errorf("no ban with id %d", banId))
+		return
+	}
+	a.logger.Info("Lifted ban", "ban_id", banId, "path", r.URL.Path)
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,80 @@
+package admin
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"server/internal/server"
+	"server/internal/server/db"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const defaultSuspectsLimit = 50
+
+type suspectJSON struct {
+	Player   string `json:"player"`
+	PlayerId int64  `json:"player_id"`
+	// As it stands now, having decayed since the last offence
+	Score         float64   `json:"score"`
+	Offences      int64     `json:"offences"`
+	LastOffence   string    `json:"last_offence"`
+	LastOffenceAt time.Time `json:"last_offence_at"`
+	Online        bool      `json:"online"`
+}
+
+// Lists the most suspicious players, going by their scores as they stand now rather than when
+// they last offended
+func (a *API) handleListSuspects(w http.ResponseWriter, r *http.Request) {
+	limit := defaultSuspectsLimit
+	if param := r.URL.Query().Get("limit"); param != "" {
+		var err error
+		if limit, err = strconv.Atoi(param); err != nil || limit <= 0 {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("invalid limit %q", param))
+			return
+		}
+	}
+
+	now := a.hub.Sim.Now()
+	halfLife := a.hub.AntiCheat.HalfLife
+
+	// Scores all decay at the same rate, but from different times, so the database has to
+	// decay them before it can tell which are the highest. Without a half-life they never decay.
+	halvingsPerSecond := 0.0
+	if halfLife > 0 {
+		halvingsPerSecond = 1 / halfLife.Seconds()
+	}
+	rows, err := a.dbTx.Queries.ListSuspicionScores(r.Context(), db.ListSuspicionScoresParams{
+		Now:               now.Unix(),
+		HalvingsPerSecond: halvingsPerSecond,
+		Limit:             int64(limit),
+	})
+	if err != nil {
+		a.logger.Error("Error listing suspicion scores", "error", err)
+		writeError(w, http.StatusInternalServerError, errors.New("error listing suspects"))
+		return
+	}
+
+	online := make(map[string]bool)
+	a.hub.Clients.ForEach(func(_ uint64, client server.ClientInterfacer) {
+		if username := client.Status().Username; username != "" {
+			online[strings.ToLower(username)] = true
+		}
+	})
+
+	list := []suspectJSON{}
+	for _, row := range rows {
+		score := server.SuspicionScore{Score: row.Score, UpdatedAt: time.Unix(row.UpdatedAt, 0)}
+		list = append(list, suspectJSON{
+			Player:        row.Name,
+			PlayerId:      row.PlayerID,
+			Score:         score.At(now, halfLife),
+			Offences:      row.Offences,
+			LastOffence:   row.LastOffence,
+			LastOffenceAt: score.UpdatedAt.UTC(),
+			Online:        online[strings.ToLower(row.Name)],
+		})
+	}
+	writeJSON(w, http.StatusOK, list)
+}
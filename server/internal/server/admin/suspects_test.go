@@ -0,0 +1,61 @@
+package admin_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"server/internal/server"
+	"server/internal/server/admin"
+	"server/internal/server/servertest"
+	"testing"
+	"time"
+)
+
+func TestListSuspectsByScoreAsItStandsNow(t *testing.T) {
+	srv := servertest.NewServer(t)
+	srv.Hub.AntiCheat.HalfLife = 5 * time.Minute
+
+	// Players are numbered from 1 in the order they register
+	names := []string{"alice", "bob", "carol"}
+	for _, name := range names {
+		srv.Register(t, srv.Connect(t), name, "secret")
+	}
+
+	now := srv.Clock.Now()
+	scores := []server.SuspicionScore{
+		// Not the highest score or the latest, but the highest once they've all decayed
+		{Score: 40, UpdatedAt: now.Add(-time.Minute)},
+		{Score: 10, UpdatedAt: now},
+		{Score: 100, UpdatedAt: now.Add(-time.Hour)},
+	}
+	for i, score := range scores {
+		if err := srv.Hub.RecordOffence(context.Background(), int64(i+1), server.OffenceOutOfReach, score); err != nil {
+			t.Fatalf("Error recording offence: %v", err)
+		}
+	}
+
+	api, err := admin.NewAPI(srv.Hub, "secret")
+	if err != nil {
+		t.Fatalf("Error creating admin API: %v", err)
+	}
+
+	request := httptest.NewRequest(http.MethodGet, "/admin/suspects?limit=1", nil)
+	request.Header.Set("Authorization", "Bearer secret")
+	response := httptest.NewRecorder()
+	api.ServeHTTP(response, request)
+
+	if response.Code != http.StatusOK {
+		t.Fatalf("Got status %d: %s", response.Code, response.Body)
+	}
+	var suspects []struct {
+		Player string `json:"player"`
+	}
+	if err := json.NewDecoder(response.Body).Decode(&suspects); err != nil {
+		t.Fatalf("Error decoding suspects: %v", err)
+	}
+
+	if len(suspects) != 1 || suspects[0].Player != "alice" {
+		t.Errorf("Most suspicious is %+v, want alice", suspects)
+	}
+}
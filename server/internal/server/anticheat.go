@@ -0,0 +1,147 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"math"
+	"server/internal/server/db"
+	"time"
+)
+
+// How many offences can be waiting to be saved before any more go unsaved
+const offenceBufferSize = 1024
+
+// Something a player's client did that a fair client wouldn't, or at least not often
+type Offence string
+
+const (
+	// Claimed to eat something out of reach, as though the player were somewhere it isn't
+	OffenceOutOfReach Offence = "out_of_reach"
+	// Claimed to eat a spore it dropped before it could have got clear of it
+	OffenceDropCooldown Offence = "drop_cooldown"
+	// Claimed to eat a player it couldn't, like a bigger or protected one
+	OffenceInvalidClaim Offence = "invalid_claim"
+	// Sent messages faster than it's allowed to
+	OffenceFlood Offence = "flood"
+	// Sent messages no client should, like ones only the server sends
+	OffenceMalformed Offence = "malformed"
+	// Sent messages claiming to be from another client
+	OffenceSpoofing Offence = "spoofing"
+)
+
+// How much each offence adds to the score. Lag makes honest clients commit the lighter ones
+// now and then, so it takes a lot of them in a short time to add up.
+var offenceWeights = map[Offence]float64{
+	OffenceOutOfReach:   1,
+	OffenceDropCooldown: 1,
+	OffenceInvalidClaim: 0.5,
+	OffenceFlood:        0.5,
+	OffenceMalformed:    2,
+	OffenceSpoofing:     5,
+}
+
+func (o Offence) Weight() float64 {
+	return offenceWeights[o]
+}
+
+type AntiCheatConfig struct {
+	// Players whose suspicion score reaches this are kicked, zero meaning never
+	KickThreshold float64
+	// How long it takes a score to halve, so the odd offence fades away
+	HalfLife time.Duration
+}
+
+var DefaultAntiCheatConfig = AntiCheatConfig{
+	KickThreshold: 50,
+	HalfLife:      5 * time.Minute,
+}
+
+// A score that halves every half-life, as it stood at a point in time
+type SuspicionScore struct {
+	Score     float64
+	UpdatedAt time.Time
+}
+
+func (s SuspicionScore) At(now time.Time, halfLife time.Duration) float64 {
+	if s.UpdatedAt.IsZero() || halfLife <= 0 {
+		return s.Score
+	}
+	elapsed := max(now.Sub(s.UpdatedAt), 0)
+	return s.Score * math.Exp2(-elapsed.Seconds()/halfLife.Seconds())
+}
+
+func (s SuspicionScore) Add(offence Offence, now time.Time, halfLife time.Duration) SuspicionScore {
+	return SuspicionScore{
+		Score:     s.At(now, halfLife) + offence.Weight(),
+		UpdatedAt: now,
+	}
+}
+
+// Picks up the player's score where it was left, which is zero for players who've never been suspected
+func (h *Hub) LoadSuspicion(ctx context.Context, playerId int64) (SuspicionScore, error) {
+	row, err := h.queries.GetSuspicionScore(ctx, playerId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return SuspicionScore{}, nil
+	}
+	if err != nil {
+		return SuspicionScore{}, err
+	}
+	return SuspicionScore{Score: row.Score, UpdatedAt: time.Unix(row.UpdatedAt, 0)}, nil
+}
+
+// Saves the player's score as it stands after the offence
+func (h *Hub) RecordOffence(ctx context.Context, playerId int64, offence Offence, score SuspicionScore) error {
+	return h.queries.RecordOffence(ctx, db.RecordOffenceParams{
+		PlayerID:    playerId,
+		Score:       score.Score,
+		LastOffence: string(offence),
+		UpdatedAt:   score.UpdatedAt.Unix(),
+	})
+}
+
+type offenceRecord struct {
+	playerId int64
+	offence  Offence
+	score    SuspicionScore
+}
+
+// Queues the player's score to be saved after the offence, without waiting on the database,
+// so offences can be reported from a transport's readers. Returns false if too many are
+// waiting to be saved already, in which case this one isn't.
+func (h *Hub) ReportOffence(playerId int64, offence Offence, score SuspicionScore) bool {
+	select {
+	case h.offences <- offenceRecord{playerId: playerId, offence: offence, score: score}:
+		return true
+	default:
+		return false
+	}
+}
+
+// Saves reported offences one at a time until the hub stops, then saves whatever's left
+func (h *Hub) recordOffencesLoop() {
+	defer h.loops.Done()
+
+	for {
+		select {
+		case record := <-h.offences:
+			h.recordReported(record)
+		case <-h.stop:
+			for {
+				select {
+				case record := <-h.offences:
+					h.recordReported(record)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+func (h *Hub) recordReported(record offenceRecord) {
+	if err := h.RecordOffence(context.Background(), record.playerId, record.offence, record.score); err != nil {
+		slog.Error("Error recording offence", "player_id", record.playerId, "offence", record.offence, "error", err)
+	}
+}
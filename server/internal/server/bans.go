@@ -0,0 +1,119 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"net/netip"
+	"server/internal/server/db"
+	"strings"
+	"time"
+)
+
+// Bans are kept in the database, so they outlast restarts. Addresses are checked whenever a
+// connection comes in, accounts whenever someone logs in.
+
+// Unix seconds as stored in the ban tables, with the zero time meaning never
+func BanTime(t time.Time) sql.NullInt64 {
+	if t.IsZero() {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: t.Unix(), Valid: true}
+}
+
+// What whoever the ban keeps out gets told
+func DescribeBan(reason string, expiresAt sql.NullInt64) string {
+	if !expiresAt.Valid {
+		return fmt.Sprintf("You are banned: %s", reason)
+	}
+	until := time.Unix(expiresAt.Int64, 0).UTC().Format("2006-01-02 15:04 MST")
+	return fmt.Sprintf("You are banned until %s: %s", until, reason)
+}
+
+// Accepts a single address as well as a range in CIDR notation
+func ParseBanPrefix(s string) (netip.Prefix, error) {
+	if prefix, err := netip.ParsePrefix(s); err == nil {
+		return prefix.Masked(), nil
+	}
+	addr, err := netip.ParseAddr(s)
+	if err != nil {
+		return netip.Prefix{}, fmt.Errorf("%q is neither an IP address nor a CIDR range", s)
+	}
+	addr = addr.Unmap()
+	return netip.PrefixFrom(addr, addr.BitLen()), nil
+}
+
+// Returns the ban covering the address, if there is one. If the bans can't be read the
+// address is let in, as locking everyone out over a database hiccup would be worse.
+func (h *Hub) checkIPBan(ctx context.Context, ip string) (db.IpBan, bool) {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return db.IpBan{}, false
+	}
+	addr = addr.Unmap()
+
+	bans, err := h.queries.ListActiveIpBans(ctx, BanTime(h.Sim.Now()))
+	if err != nil {
+		slog.Error("Error checking IP bans, letting the connection in", "ip", ip, "error", err)
+		return db.IpBan{}, false
+	}
+
+	for _, ban := range bans {
+		prefix, err := netip.ParsePrefix(ban.Cidr)
+		if err != nil {
+			slog.Error("Ignoring IP ban with invalid CIDR", "ban_id", ban.ID, "cidr", ban.Cidr, "error", err)
+			continue
+		}
+		if prefix.Contains(addr) {
+			return ban, true
+		}
+	}
+	return db.IpBan{}, false
+}
+
+// Bans the addresses in the prefix until the expiry (or for good, if it's zero) and kicks
+// everyone already connected from them
+func (h *Hub) BanIP(ctx context.Context, prefix netip.Prefix, reason string, expiresAt time.Time) (db.IpBan, error) {
+	ban, err := h.queries.CreateIpBan(ctx, db.CreateIpBanParams{
+		Cidr:      prefix.String(),
+		Reason:    reason,
+		ExpiresAt: BanTime(expiresAt),
+	})
+	if err != nil {
+		return ban, err
+	}
+	slog.Info("Banned address", "ban_id", ban.ID, "cidr", ban.Cidr, "ban", DescribeBan(ban.Reason, ban.ExpiresAt))
+
+	h.Clients.ForEach(func(clientId uint64, client ClientInterfacer) {
+		ip, connected := h.connections.ip(client)
+		if !connected {
+			return
+		}
+		if addr, err := netip.ParseAddr(ip); err == nil && prefix.Contains(addr.Unmap()) {
+			h.Kick(clientId, DescribeBan(ban.Reason, ban.ExpiresAt))
+		}
+	})
+	return ban, nil
+}
+
+// Stops the user logging in until the expiry (or for good, if it's zero) and kicks them if
+// they're online
+func (h *Hub) BanUser(ctx context.Context, user db.User, reason string, expiresAt time.Time) (db.UserBan, error) {
+	ban, err := h.queries.CreateUserBan(ctx, db.CreateUserBanParams{
+		UserID:    user.ID,
+		Reason:    reason,
+		ExpiresAt: BanTime(expiresAt),
+	})
+	if err != nil {
+		return ban, err
+	}
+	slog.Info("Banned user", "ban_id", ban.ID, "username", user.Username, "ban", DescribeBan(ban.Reason, ban.ExpiresAt))
+
+	h.Clients.ForEach(func(clientId uint64, client ClientInterfacer) {
+		if strings.EqualFold(client.Status().Username, user.Username) {
+			h.Kick(clientId, DescribeBan(ban.Reason, ban.ExpiresAt))
+		}
+	})
+	return ban, nil
+}
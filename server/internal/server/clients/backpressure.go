@@ -0,0 +1,29 @@
+package clients
+
+import "server/pkg/packets"
+
+// What to do with a message when there's no room left to queue it for the client
+type OverflowAction int
+
+const (
+	// Throw the message away, the client can live without it
+	OverflowDrop OverflowAction = iota
+	// Hold on to the message until there's room, replacing it if a newer one of the same kind comes along
+	OverflowCoalesce
+	// The client can't be kept in sync any more, so disconnect it
+	OverflowDisconnect
+)
+
+type OverflowPolicy func(message packets.Msg) OverflowAction
+
+// Player updates are superseded by the next one anyway, and a client missing a few spores
+// is only cosmetic, but falling behind on anything else leaves the client out of sync
+func DefaultOverflowPolicy(message packets.Msg) OverflowAction {
+	switch message.(type) {
+	case *packets.Packet_Player:
+		return OverflowCoalesce
+	case *packets.Packet_Spore, *packets.Packet_SporeBatch:
+		return OverflowDrop
+	}
+	return OverflowDisconnect
+}
@@ -0,0 +1,295 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"server/internal/server"
+	"server/internal/server/objects"
+	"server/internal/server/sim"
+	"server/internal/server/states"
+	"server/pkg/packets"
+	"slices"
+	"strings"
+	"time"
+)
+
+type BotDifficulty struct {
+	Name string
+	// How often the bot re-evaluates its surroundings
+	ThinkInterval time.Duration
+	// How far the bot can see other players and spores
+	SightRange float64
+	// Chance per think that the bot goes after a smaller player it can see
+	Aggression float64
+	// Multiplier on the sight range used to spot bigger players to run from
+	Caution float64
+}
+
+var (
+	BotEasy = BotDifficulty{
+		Name:          "easy",
+		ThinkInterval: 600 * time.Millisecond,
+		SightRange:    400,
+		Aggression:    0.2,
+		Caution:       0.5,
+	}
+	BotMedium = BotDifficulty{
+		Name:          "medium",
+		ThinkInterval: 300 * time.Millisecond,
+		SightRange:    700,
+		Aggression:    0.6,
+		Caution:       0.8,
+	}
+	BotHard = BotDifficulty{
+		Name:          "hard",
+		ThinkInterval: 150 * time.Millisecond,
+		SightRange:    1000,
+		Aggression:    1,
+		Caution:       1,
+	}
+)
+
+func ParseBotDifficulty(name string) (BotDifficulty, error) {
+	switch strings.ToLower(name) {
+	case BotEasy.Name:
+		return BotEasy, nil
+	case BotMedium.Name:
+		return BotMedium, nil
+	case BotHard.Name:
+		return BotHard, nil
+	}
+	return BotDifficulty{}, fmt.Errorf("unknown bot difficulty %q", name)
+}
+
+var botNames = []string{"Amoeba", "Blob", "Cell", "Dot", "Germ", "Microbe", "Plankton", "Spore", "Yeast", "Zygote"}
+
+type BotClient struct {
+	clientCore
+	difficulty BotDifficulty
+}
+
+func NewBotClient(hub *server.Hub, difficulty BotDifficulty) *BotClient {
+	c := &BotClient{
+		difficulty: difficulty,
+	}
+	c.initCore(c, hub, slog.Default().With("transport", "bot", "difficulty", difficulty.Name))
+	return c
+}
+
+func (c *BotClient) Initialize(id uint64) {
+	// The bot's randomness comes from its id, so it needs that first
+	c.assignId(id)
+
+	rng := c.Sim().Rand
+	player := &objects.Player{
+		Name:  fmt.Sprintf("%s %d", botNames[rng.IntN(len(botNames))], id),
+		Color: int32(rng.Uint32() | 0xff),
+		IsBot: true,
+	}
+	c.Identify(player)
+	c.enter(states.NewInGame(player))
+}
+
+// Bots have no socket, everything the state wants to send to the client is discarded
+func (c *BotClient) SocketSend(message packets.Msg) {}
+
+func (c *BotClient) SocketSendAs(message packets.Msg, senderId uint64) {}
+
+// The bot's "read pump" is its brain: instead of reading packets off a socket, it
+// looks at the shared game objects and feeds itself the packets a player would send
+func (c *BotClient) ReadPump() {
+	ticker := time.NewTicker(c.difficulty.ThinkInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			c.Post(c.think)
+		case <-c.done:
+			return
+		}
+	}
+}
+
+func (c *BotClient) WritePump() {
+	<-c.done
+}
+
+// Runs on the event loop, so the bot acts on its decisions straight away
+func (c *BotClient) think() {
+	game, inGame := c.state.(*states.InGame)
+	if !inGame {
+		// We've been eaten, so get straight back in
+		c.handleMessage(c.Id(), &packets.Packet_RespawnRequest{})
+		return
+	}
+	// The bot's own player for this life, which spores it drops point to, rather than the
+	// snapshot of it everyone else sees
+	me := game.Player()
+
+	myMass := sim.RadToMass(me.Radius)
+	sightSq := c.difficulty.SightRange * c.difficulty.SightRange
+	fleeRange := c.difficulty.SightRange * c.difficulty.Caution
+	fleeSq := fleeRange * fleeRange
+
+	var threat, prey *objects.Player
+	var preyId uint64
+	threatDistSq, preyDistSq := math.Inf(1), math.Inf(1)
+
+	c.SharedGameObjects().Players.ForEach(func(playerId uint64, other *objects.Player) {
+		if playerId == c.Id() {
+			return
+		}
+		distSq := distanceSq(me.X, me.Y, other.X, other.Y)
+		otherMass := sim.RadToMass(other.Radius)
+
+		if otherMass > myMass*1.5 && distSq < fleeSq && distSq < threatDistSq {
+			threat, threatDistSq = other, distSq
+		} else if myMass > otherMass*1.5 && !other.IsSpawnProtected(c.Sim().Now()) && distSq < sightSq && distSq < preyDistSq {
+			prey, preyId, preyDistSq = other, playerId, distSq
+		}
+	})
+
+	if threat != nil {
+		c.steer(math.Atan2(me.Y-threat.Y, me.X-threat.X))
+		return
+	}
+
+	if prey != nil && c.Sim().Rand.Float64() < c.difficulty.Aggression {
+		if preyDistSq <= me.Radius*me.Radius {
+			c.handleMessage(c.Id(), &packets.Packet_PlayerConsumed{
+				PlayerConsumed: &packets.PlayerConsumedMessage{PlayerId: preyId},
+			})
+			return
+		}
+		c.steer(math.Atan2(prey.Y-me.Y, prey.X-me.X))
+		return
+	}
+
+	var target *objects.Spore
+	targetDistSq := sightSq
+	now := c.Sim().Now()
+	c.SharedGameObjects().Spores.ForEach(func(sporeId uint64, spore *objects.Spore) {
+		// Spores the bot has only just dropped are right underneath it, and aren't its to eat yet
+		if sim.CheckDropCooldown(me, spore, sim.DropClearance, now) != nil {
+			return
+		}
+
+		distSq := distanceSq(me.X, me.Y, spore.X, spore.Y)
+		reach := me.Radius + spore.Radius
+		if distSq <= reach*reach {
+			c.handleMessage(c.Id(), &packets.Packet_SporeConsumed{
+				SporeConsumed: &packets.SporeConsumedMessage{SporeId: sporeId},
+			})
+			return
+		}
+		if distSq < targetDistSq {
+			target, targetDistSq = spore, distSq
+		}
+	})
+
+	if target != nil {
+		c.steer(math.Atan2(target.Y-me.Y, target.X-me.X))
+		return
+	}
+
+	// Nothing interesting in sight, wander around
+	c.steer(me.Direction + (c.Sim().Rand.Float64()-0.5)*math.Pi/4)
+}
+
+func (c *BotClient) steer(direction float64) {
+	c.handleMessage(c.Id(), &packets.Packet_PlayerDirection{
+		PlayerDirection: &packets.PlayerDirectionMessage{Direction: direction},
+	})
+}
+
+func distanceSq(x1, y1, x2, y2 float64) float64 {
+	dx := x2 - x1
+	dy := y2 - y1
+	return dx*dx + dy*dy
+}
+
+// Keeps the number of players in game at a target population by adding bots when
+// there aren't enough real players around, and removing them as real players join
+type BotManager struct {
+	hub              *server.Hub
+	difficulty       BotDifficulty
+	targetPopulation int
+	bots             []*BotClient
+	logger           *slog.Logger
+}
+
+func NewBotManager(hub *server.Hub, difficulty BotDifficulty, targetPopulation int) *BotManager {
+	return &BotManager{
+		hub:              hub,
+		difficulty:       difficulty,
+		targetPopulation: targetPopulation,
+		logger:           slog.Default().With("component", "bot_manager"),
+	}
+}
+
+// Stops adding and removing bots once the context is done, the bots themselves are
+// closed along with every other client when the hub shuts down
+func (m *BotManager) Run(ctx context.Context, rate time.Duration) {
+	ticker := time.NewTicker(rate)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+
+		m.forgetClosedBots()
+
+		realPlayers := 0
+		m.hub.SharedGameObjects.Players.ForEach(func(_ uint64, player *objects.Player) {
+			if !player.IsBot {
+				realPlayers++
+			}
+		})
+
+		wantedBots := max(0, m.targetPopulation-realPlayers)
+
+		// Only add or remove one bot at a time so the population changes gradually
+		if len(m.bots) < wantedBots {
+			m.addBot()
+		} else if len(m.bots) > wantedBots {
+			m.removeBot()
+		}
+	}
+}
+
+func (m *BotManager) addBot() {
+	bot := NewBotClient(m.hub, m.difficulty)
+	m.hub.Register(bot)
+
+	m.bots = append(m.bots, bot)
+	m.logger.Info("Added bot", "difficulty", m.difficulty.Name, "bots", len(m.bots))
+}
+
+// Bots can leave without the manager removing them, like when an admin kicks them, and
+// those need replacing
+func (m *BotManager) forgetClosedBots() {
+	m.bots = slices.DeleteFunc(m.bots, func(bot *BotClient) bool {
+		select {
+		case <-bot.done:
+			m.logger.Info("Bot left", "client_id", bot.Id())
+			return true
+		default:
+			return false
+		}
+	})
+}
+
+func (m *BotManager) removeBot() {
+	last := len(m.bots) - 1
+	bot := m.bots[last]
+	m.bots = m.bots[:last]
+
+	bot.Close("no longer needed")
+	m.logger.Info("Removed bot", "client_id", bot.Id(), "bots", len(m.bots))
+}
@@ -0,0 +1,49 @@
+package clients_test
+
+import (
+	"context"
+	"server/internal/server/clients"
+	"server/internal/server/objects"
+	"server/internal/server/servertest"
+	"testing"
+	"time"
+)
+
+// Waits for a bot other than the one given to be in game, returning its id
+func expectBot(t *testing.T, srv *servertest.Server, notId uint64) uint64 {
+	t.Helper()
+
+	deadline := time.Now().Add(servertest.Timeout)
+	for {
+		var botId uint64
+		srv.Hub.SharedGameObjects.Players.ForEach(func(playerId uint64, player *objects.Player) {
+			if player.IsBot && playerId != notId {
+				botId = playerId
+			}
+		})
+		if botId != 0 {
+			return botId
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("No bot joined the game")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestBotManagerReplacesKickedBots(t *testing.T) {
+	srv := servertest.NewServer(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go clients.NewBotManager(srv.Hub, clients.BotEasy, 1).Run(ctx, 10*time.Millisecond)
+
+	botId := expectBot(t, srv, 0)
+	bot, exists := srv.Hub.Clients.Get(botId)
+	if !exists {
+		t.Fatalf("Bot %d isn't registered", botId)
+	}
+	bot.Close("kicked by an admin")
+
+	expectBot(t, srv, botId)
+}
@@ -0,0 +1,407 @@
+package clients
+
+import (
+	"fmt"
+	"log/slog"
+	"server/internal/server"
+	"server/internal/server/metrics"
+	"server/internal/server/objects"
+	"server/internal/server/replay"
+	"server/internal/server/sim"
+	"server/internal/server/states"
+	"server/pkg/packets"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const mailboxSize = 256
+
+// The transport-agnostic half of a client. Everything that touches the client's state runs
+// on the client's own event loop, one event at a time, so states never see concurrent calls.
+// Other goroutines (the hub, peers, timers, the transport's pumps) only ever post events.
+//
+// The event loop owns the client's teardown: Close only asks for it, and the loop closes
+// done once the client has left its state and the hub. Transports watch done to know when
+// to stop their pumps, rather than having channels closed underneath them.
+type clientCore struct {
+	id      atomic.Uint64
+	client  server.ClientInterfacer // The transport-specific client embedding this core
+	hub     *server.Hub
+	state   server.ClientStateHandler
+	dbTx    *server.DbTx
+	mailbox chan func()
+	done    chan struct{}
+
+	initialState chan server.ClientStateHandler
+	closeOnce    sync.Once
+	closeReason  chan string
+
+	overflowPolicy OverflowPolicy
+	lagging        atomic.Bool
+
+	// Checks packets read by the transport before they reach the event loop
+	inbound *inboundFilter
+
+	// Round trip time in nanoseconds, for transports that measure it
+	rtt atomic.Int64
+	// Unix nanoseconds of the last message received from the client
+	lastActivity atomic.Int64
+
+	// Logs with everything known about the client so far: how it connected, its id and who it's logged in as
+	logger     atomic.Pointer[slog.Logger]
+	baseLogger *slog.Logger
+	username   string
+
+	// Republished whenever the state or username changes, for anyone outside the event loop
+	status atomic.Pointer[server.ClientStatus]
+
+	suspicion suspicion
+
+	// Forked from the hub's by client id once the client starts
+	sim *sim.Sim
+}
+
+// Must be called once the transport-specific client exists, since the event loop starts right away
+func (c *clientCore) initCore(client server.ClientInterfacer, hub *server.Hub, logger *slog.Logger) {
+	c.client = client
+	c.hub = hub
+	c.baseLogger = logger
+	c.logger.Store(logger)
+	c.dbTx = hub.NewDbTx()
+	c.mailbox = make(chan func(), mailboxSize)
+	c.done = make(chan struct{})
+	c.initialState = make(chan server.ClientStateHandler, 1)
+	c.closeReason = make(chan string, 1)
+	c.overflowPolicy = DefaultOverflowPolicy
+	c.inbound = newInboundFilter(DefaultInboundConfig)
+	c.touch()
+
+	go c.runEventLoop()
+}
+
+func (c *clientCore) Id() uint64 {
+	return c.id.Load()
+}
+
+func (c *clientCore) SetState(state server.ClientStateHandler) {
+	prevStateName := "None"
+
+	if c.state != nil {
+		prevStateName = c.state.Name()
+		c.state.OnExit()
+		metrics.ClientsByState.Add(prevStateName, -1)
+	}
+	newStateName := "None"
+
+	if state != nil {
+		newStateName = state.Name()
+		metrics.ClientsByState.Add(newStateName, 1)
+	}
+	c.Logger().Debug("Switching state", "from", prevStateName, "to", newStateName)
+
+	c.state = state
+	c.publishStatus()
+	if c.state != nil {
+		c.state.SetClient(c.client)
+		c.state.OnEnter()
+	}
+}
+
+// Assigns the client its id and puts it in its initial state. Called from the hub, so this
+// never waits on the event loop.
+func (c *clientCore) start(id uint64, initialState server.ClientStateHandler) {
+	c.assignId(id)
+	c.enter(initialState)
+}
+
+// The first half of start, for transports that need the id before the client can start
+func (c *clientCore) assignId(id uint64) {
+	c.id.Store(id)
+	c.sim = c.hub.Sim.Fork(id)
+	c.updateLogger()
+}
+
+// The second half of start
+func (c *clientCore) enter(initialState server.ClientStateHandler) {
+	c.initialState <- initialState
+}
+
+func (c *clientCore) Logger() *slog.Logger {
+	return c.logger.Load()
+}
+
+// Attaches the player's name to everything the client logs from now on, and picks up the
+// player's suspicion score where it was left. Detaches both if the player is nil. Only called
+// from the event loop, or before the client starts.
+func (c *clientCore) Identify(player *objects.Player) {
+	c.username = ""
+	var playerId int64
+	var score server.SuspicionScore
+
+	if player != nil {
+		c.username = player.Name
+		if !player.IsBot {
+			playerId = player.DbId
+		}
+	}
+	c.updateLogger()
+	c.publishStatus()
+
+	if playerId != 0 {
+		var err error
+		if score, err = c.hub.LoadSuspicion(c.dbTx.Ctx, playerId); err != nil {
+			c.Logger().Error("Error loading suspicion score, starting from zero", "error", err)
+		}
+	}
+	c.suspicion.reset(playerId, score)
+}
+
+func (c *clientCore) State() server.ClientStateHandler {
+	return c.state
+}
+
+func (c *clientCore) Status() server.ClientStatus {
+	if status := c.status.Load(); status != nil {
+		return *status
+	}
+	return server.ClientStatus{}
+}
+
+func (c *clientCore) publishStatus() {
+	status := server.ClientStatus{Username: c.username}
+	if c.state != nil {
+		status.State = c.state.Name()
+	}
+	c.status.Store(&status)
+}
+
+func (c *clientCore) updateLogger() {
+	logger := c.baseLogger.With("client_id", c.Id())
+	if c.username != "" {
+		logger = logger.With("username", c.username)
+	}
+	c.logger.Store(logger)
+}
+
+func (c *clientCore) runEventLoop() {
+	// Anything the client sends before it's in a state has nowhere to go, so it waits in the mailbox
+	select {
+	case state := <-c.initialState:
+		c.SetState(state)
+	case reason := <-c.closeReason:
+		c.shutdown(reason)
+		return
+	}
+
+	for {
+		select {
+		case event := <-c.mailbox:
+			event()
+		case reason := <-c.closeReason:
+			c.shutdown(reason)
+			return
+		}
+	}
+}
+
+// Queues the message for the client's state without waiting. This is how the hub and peers
+// reach the client, so a client that has fallen behind loses messages rather than holding up
+// everyone else.
+func (c *clientCore) ProcessMessage(senderId uint64, message packets.Msg) {
+	select {
+	case c.mailbox <- func() { c.handleMessage(senderId, message) }:
+	case <-c.done:
+	default:
+		// There's nothing to coalesce into on the mailbox, but the next update will supersede this one anyway
+		if c.overflow(message) == OverflowCoalesce {
+			c.hub.Drops.Dropped.Add(1)
+		}
+	}
+}
+
+// Applies the client's overflow policy to a message there's no room for, leaving coalescing up
+// to the caller since only it knows where the message is waiting to go
+func (c *clientCore) overflow(message packets.Msg) OverflowAction {
+	action := c.overflowPolicy(message)
+
+	switch action {
+	case OverflowDrop:
+		c.hub.Drops.Dropped.Add(1)
+	case OverflowDisconnect:
+		if c.lagging.CompareAndSwap(false, true) {
+			c.Logger().Warn("Can't keep up, disconnecting", "message_type", fmt.Sprintf("%T", message))
+			c.hub.Drops.Disconnected.Add(1)
+			c.Close("too slow to keep up")
+		}
+	}
+
+	return action
+}
+
+// Runs the event on the client's event loop, waiting for room in the mailbox if need be.
+// Events posted after the client has shut down are discarded.
+func (c *clientCore) Post(event func()) {
+	select {
+	case c.mailbox <- event:
+	case <-c.done:
+	}
+}
+
+// Hands a packet the transport read off the wire to the event loop, unless the inbound filter
+// rejects it. Unlike messages from the hub, this waits for room in the mailbox, which pushes
+// back on a client that sends too fast.
+func (c *clientCore) receive(packet *packets.Packet) {
+	if c.admit(packet) {
+		c.Post(c.inboundEvent(packet))
+	}
+}
+
+// Like receive, for transports shared between clients that can't be held up by any one of
+// them. If the client's mailbox is full the packet is dropped instead.
+func (c *clientCore) receiveWithoutWaiting(packet *packets.Packet) {
+	if !c.admit(packet) {
+		return
+	}
+
+	select {
+	case c.mailbox <- c.inboundEvent(packet):
+	default:
+		c.hub.Drops.Dropped.Add(1)
+	}
+}
+
+// Checks a packet read off the wire, returning whether it's fit for the event loop
+func (c *clientCore) admit(packet *packets.Packet) bool {
+	metrics.PacketsIn.Inc(packets.MessageType(packet))
+
+	// Whoever is on the other end of the connection sent this, so it can't claim to be from anyone else.
+	// The client doesn't need to fill in its id, though, as it might not know it yet.
+	if packet.SenderId != 0 && packet.SenderId != c.Id() {
+		c.reject(packet, server.OffenceSpoofing, fmt.Errorf("protocol violation: %s message claims to be from client %d", packets.MessageType(packet), packet.SenderId))
+		return false
+	}
+
+	if offence, err := c.inbound.check(packet, time.Now()); err != nil {
+		c.reject(packet, offence, err)
+		return false
+	}
+
+	return true
+}
+
+func (c *clientCore) inboundEvent(packet *packets.Packet) func() {
+	return func() {
+		packet.SenderId = c.Id()
+		c.handleMessage(packet.SenderId, packet.Msg)
+	}
+}
+
+// Throws away a packet from the client, kicking the client if it's been doing this too often
+func (c *clientCore) reject(packet *packets.Packet, offence server.Offence, err error) {
+	metrics.PacketsRejected.Inc(packets.MessageType(packet))
+	c.Suspect(offence, err)
+
+	if c.inbound.violation(time.Now()) {
+		c.Logger().Warn("Kicking client for sending too many bad messages", "error", err)
+		c.kick(fmt.Sprintf("sent too many bad messages (%v)", err))
+		return
+	}
+	c.Logger().Warn("Rejected message", "error", err)
+}
+
+// Tells the client why it's being disconnected before closing it
+func (c *clientCore) kick(reason string) {
+	c.client.SocketSend(packets.NewDisconnect(reason))
+	c.Close(reason)
+}
+
+func (c *clientCore) handleMessage(senderId uint64, message packets.Msg) {
+	if c.state != nil {
+		c.state.HandleMessage(senderId, message)
+	}
+}
+
+// Every transport but TCP with datagrams sends everything reliably anyway
+func (c *clientCore) SocketSendReliably(message packets.Msg, senderId uint64) {
+	c.client.SocketSendAs(message, senderId)
+}
+
+func (c *clientCore) PassToPeer(message packets.Msg, peerId uint64) {
+	if peer, exists := c.hub.Clients.Get(peerId); exists {
+		peer.ProcessMessage(c.Id(), message)
+	}
+}
+
+func (c *clientCore) Broadcast(message packets.Msg) {
+	c.hub.Broadcast(&packets.Packet{SenderId: c.Id(), Msg: message})
+}
+
+// Asks the event loop to tear the client down. Safe to call any number of times, from any
+// goroutine including the event loop itself; only the first reason is used.
+func (c *clientCore) Close(reason string) {
+	c.closeOnce.Do(func() {
+		c.closeReason <- reason
+	})
+}
+
+// Lets everyone know the client has left, leaves the current state and stops the event
+// loop. Only ever runs once, on the event loop.
+func (c *clientCore) shutdown(reason string) {
+	c.Logger().Info("Closing client", "reason", reason)
+
+	// Without an id nobody has heard of the client yet, so there's nobody to tell
+	if c.Id() != 0 {
+		c.Broadcast(packets.NewDisconnect(reason))
+	}
+
+	c.SetState(nil)
+
+	// Always safe, as the hub handles a client's registration before anything can close it
+	c.hub.Unregister(c.client)
+	close(c.done)
+}
+
+// Notes that the client just sent something, for transports to call whenever they read from it
+func (c *clientCore) touch() {
+	c.lastActivity.Store(time.Now().UnixNano())
+}
+
+// Closes the client if it hasn't sent anything for longer than the timeout, unless it's
+// playing, spectating or watching a replay, where it might well have nothing to say. Runs on
+// the event loop, since it depends on the client's state.
+func (c *clientCore) checkIdle(timeout time.Duration) {
+	switch c.state.(type) {
+	case *states.InGame, *states.Spectating, *states.Replaying:
+		return
+	}
+
+	idle := time.Since(time.Unix(0, c.lastActivity.Load()))
+	if idle > timeout {
+		c.Close(fmt.Sprintf("idle for %v", idle.Round(time.Second)))
+	}
+}
+
+func (c *clientCore) RTT() time.Duration {
+	return time.Duration(c.rtt.Load())
+}
+
+func (c *clientCore) SharedGameObjects() *server.SharedGameObjects {
+	return c.hub.SharedGameObjects
+}
+
+func (c *clientCore) Settings() server.GameSettings {
+	return c.hub.Settings()
+}
+
+func (c *clientCore) Sim() *sim.Sim {
+	return c.sim
+}
+
+func (c *clientCore) Replays() *replay.Library {
+	return c.hub.Replays
+}
+
+func (c *clientCore) DbTx() *server.DbTx {
+	return c.dbTx
+}
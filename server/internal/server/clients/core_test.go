@@ -0,0 +1,133 @@
+package clients_test
+
+import (
+	"fmt"
+	"server/internal/server/clients"
+	"server/internal/server/servertest"
+	"server/internal/server/states"
+	"sync"
+	"testing"
+	"time"
+)
+
+func waitDone(t *testing.T, client *clients.LoopbackClient) {
+	t.Helper()
+
+	select {
+	case <-client.Done():
+	case <-time.After(servertest.Timeout):
+		t.Fatal("Client never shut down")
+	}
+}
+
+func TestPostRunsEventsInOrder(t *testing.T) {
+	srv := servertest.NewServer(t)
+	client := srv.Connect(t)
+
+	const posters, eventsEach = 8, 200
+
+	// Only ever touched from the event loop, so it needs no lock
+	seen := make(map[int][]int)
+
+	var wg sync.WaitGroup
+	for poster := range posters {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range eventsEach {
+				client.Post(func() { seen[poster] = append(seen[poster], i) })
+			}
+		}()
+	}
+	wg.Wait()
+
+	flushed := make(chan struct{})
+	client.Post(func() { close(flushed) })
+	<-flushed
+
+	for poster := range posters {
+		events := seen[poster]
+		if len(events) != eventsEach {
+			t.Fatalf("Poster %d: ran %d events, want %d", poster, len(events), eventsEach)
+		}
+		for i, event := range events {
+			if event != i {
+				t.Fatalf("Poster %d: event %d ran in position %d", poster, event, i)
+			}
+		}
+	}
+}
+
+func TestEventsWaitForInitialState(t *testing.T) {
+	srv := servertest.NewServer(t)
+	client := clients.NewLoopbackClient(srv.Hub)
+
+	stateName := make(chan string, 1)
+	go client.Post(func() {
+		if state := client.State(); state != nil {
+			stateName <- state.Name()
+		} else {
+			stateName <- "None"
+		}
+	})
+
+	// Give the event every chance to run early before the client has a state to run it in
+	time.Sleep(10 * time.Millisecond)
+	srv.Hub.Register(client)
+
+	select {
+	case name := <-stateName:
+		if want := (&states.Connected{}).Name(); name != want {
+			t.Fatalf("Event ran in state %s, want %s", name, want)
+		}
+	case <-time.After(servertest.Timeout):
+		t.Fatal("Event never ran")
+	}
+}
+
+func TestCloseFromAnyGoroutine(t *testing.T) {
+	srv := servertest.NewServer(t)
+	client := srv.Connect(t)
+	id := client.Id()
+
+	var wg sync.WaitGroup
+	for i := range 16 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			client.Close(fmt.Sprintf("closed by goroutine %d", i))
+		}()
+	}
+	// The event loop closing its own client mustn't deadlock on the close above it
+	client.Post(func() { client.Close("closed by the event loop") })
+	wg.Wait()
+
+	waitDone(t, client)
+
+	if client.State() != nil {
+		t.Errorf("Client is still in state %s after shutting down", client.State().Name())
+	}
+
+	// Closing again, and posting to a client that's gone, must return straight away
+	client.Close("closed again")
+	client.Post(func() { t.Error("Event ran after the client shut down") })
+
+	deadline := time.Now().Add(servertest.Timeout)
+	for {
+		if _, exists := srv.Hub.Clients.Get(id); !exists {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("Client is still registered with the hub")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestCloseBeforeInitialState(t *testing.T) {
+	srv := servertest.NewServer(t)
+	client := clients.NewLoopbackClient(srv.Hub)
+
+	client.Close("never got going")
+	waitDone(t, client)
+}
@@ -0,0 +1,180 @@
+package clients
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"log/slog"
+	"net"
+	"server/internal/server/metrics"
+	"server/pkg/packets"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// Datagrams from the client start with the token it was given over TCP, as a big-endian
+	// integer, followed by a protobuf packet. Datagrams to the client are just the packet.
+	datagramTokenSize = 8
+	maxDatagramSize   = 1500
+)
+
+type datagramBinding struct {
+	// Nil while the token is reserved but not yet bound
+	client *TCPClient
+	// Where the client last sent a datagram from, and where its datagrams go, or nil until it
+	// sends its first one
+	addr *net.UDPAddr
+}
+
+// A UDP socket shared by every TCP client, for position updates that are better off late or
+// lost than holding up everything behind them. A client is bound to the address it sends its
+// token from, which follows it if its address changes.
+type DatagramServer struct {
+	conn     *net.UDPConn
+	mux      sync.Mutex
+	bindings map[uint64]*datagramBinding
+}
+
+func ListenDatagrams(address string) (*DatagramServer, error) {
+	addr, err := net.ResolveUDPAddr("udp", address)
+	if err != nil {
+		return nil, err
+	}
+	conn, err := net.ListenUDP("udp", addr)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DatagramServer{
+		conn:     conn,
+		bindings: make(map[uint64]*datagramBinding),
+	}, nil
+}
+
+// Reads datagrams and passes them on to their clients, until the server is closed
+func (s *DatagramServer) Serve() error {
+	buffer := make([]byte, maxDatagramSize)
+
+	for {
+		n, addr, err := s.conn.ReadFromUDP(buffer)
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return err
+		}
+		if n < datagramTokenSize {
+			continue
+		}
+
+		client, ok := s.bound(binary.BigEndian.Uint64(buffer), addr)
+		if !ok {
+			continue
+		}
+
+		packet := &packets.Packet{}
+		if err := proto.Unmarshal(buffer[datagramTokenSize:n], packet); err != nil {
+			client.Logger().Warn("Error unmarshalling datagram", "error", err)
+			continue
+		}
+		// Never waits on the client, since every other client's datagrams are behind this one
+		client.receiveDatagram(packet)
+	}
+}
+
+// The address the server is listening on, with the port filled in if it was left to the system
+func (s *DatagramServer) Addr() net.Addr {
+	return s.conn.LocalAddr()
+}
+
+func (s *DatagramServer) Close() error {
+	return s.conn.Close()
+}
+
+// Returns a new token for a client to send its datagrams with. Datagrams sent with it are
+// ignored until the client is bound to it.
+func (s *DatagramServer) reserve() uint64 {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	tokenBytes := make([]byte, datagramTokenSize)
+	for {
+		rand.Read(tokenBytes)
+		token := binary.BigEndian.Uint64(tokenBytes)
+		if _, exists := s.bindings[token]; token != 0 && !exists {
+			s.bindings[token] = &datagramBinding{}
+			return token
+		}
+	}
+}
+
+// Starts passing datagrams sent with the reserved token on to the client
+func (s *DatagramServer) bind(token uint64, client *TCPClient) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	if binding, exists := s.bindings[token]; exists {
+		binding.client = client
+	}
+}
+
+func (s *DatagramServer) unbind(token uint64) {
+	if s == nil {
+		return
+	}
+
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	delete(s.bindings, token)
+}
+
+// Finds the client the token belongs to, remembering the address it sent it from
+func (s *DatagramServer) bound(token uint64, addr *net.UDPAddr) (*TCPClient, bool) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	binding, exists := s.bindings[token]
+	if !exists || binding.client == nil {
+		return nil, false
+	}
+	if binding.addr == nil || binding.addr.String() != addr.String() {
+		if binding.addr != nil {
+			binding.client.Logger().Info("Datagrams moved", "from", binding.addr.String(), "to", addr.String())
+		}
+		binding.addr = addr
+	}
+	return binding.client, true
+}
+
+// Sends the packet to the client the token belongs to, returning false if it can't be sent
+// as a datagram, e.g. because the client hasn't sent one yet
+func (s *DatagramServer) send(token uint64, packet *packets.Packet) bool {
+	if s == nil {
+		return false
+	}
+
+	s.mux.Lock()
+	binding, exists := s.bindings[token]
+	var addr *net.UDPAddr
+	if exists {
+		addr = binding.addr
+	}
+	s.mux.Unlock()
+
+	if addr == nil {
+		return false
+	}
+
+	data, err := proto.Marshal(packet)
+	if err != nil || len(data) > maxDatagramSize {
+		return false
+	}
+	if _, err := s.conn.WriteToUDP(data, addr); err != nil {
+		slog.Error("Error sending datagram", "addr", addr.String(), "error", err)
+	}
+	metrics.PacketsOut.Inc(packets.MessageType(packet))
+	return true
+}
@@ -0,0 +1,14 @@
+package clients
+
+import "net"
+
+// Where datagrams sent with the token go, nil until the client has sent one
+func (s *DatagramServer) BoundAddr(token uint64) *net.UDPAddr {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	if binding, exists := s.bindings[token]; exists {
+		return binding.addr
+	}
+	return nil
+}
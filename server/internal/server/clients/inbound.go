@@ -0,0 +1,188 @@
+package clients
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"server/internal/server"
+	"server/pkg/packets"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	maxChatLength       = 256
+	maxUsernameLength   = 20
+	maxPasswordLength   = 72 // bcrypt ignores anything past this
+	maxDisconnectLength = 256
+	maxReplayNameLength = 32
+	maxReplaySpeed      = 16
+)
+
+// Lets through bursts of up to Burst messages, refilling at Rate messages a second
+type RateBudget struct {
+	Rate  float64
+	Burst float64
+}
+
+type InboundConfig struct {
+	// Budgets per message type, keyed by the message's field name in the packet, e.g. "player_direction"
+	Budgets map[string]RateBudget
+	// Budget for each message type not in Budgets
+	DefaultBudget RateBudget
+	// Every message that's invalid or over budget uses this up, and the client is kicked once it runs out
+	Violations RateBudget
+}
+
+var DefaultInboundConfig = InboundConfig{
+	Budgets: map[string]RateBudget{
+		// The client sends a new direction at most once a physics frame
+		"player_direction": {Rate: 60, Burst: 120},
+		// Moving through a cluster of spores eats a lot of them at once
+		"spore_consumed":    {Rate: 50, Burst: 100},
+		"player_consumed":   {Rate: 10, Burst: 20},
+		"power_up_consumed": {Rate: 5, Burst: 10},
+		"chat":              {Rate: 1, Burst: 5},
+		// Each of these costs a bcrypt hash
+		"login_request":    {Rate: 0.5, Burst: 5},
+		"register_request": {Rate: 0.5, Burst: 5},
+	},
+	DefaultBudget: RateBudget{Rate: 5, Burst: 20},
+	Violations:    RateBudget{Rate: 1, Burst: 20},
+}
+
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+func (b *tokenBucket) take(budget RateBudget, now time.Time) bool {
+	if b.last.IsZero() {
+		b.tokens = budget.Burst
+	} else {
+		b.tokens = min(budget.Burst, b.tokens+now.Sub(b.last).Seconds()*budget.Rate)
+	}
+	b.last = now
+
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// Sits between a transport reading packets off the wire and the client's event loop, throwing
+// away packets that are malformed or sent too often. Safe to use from several readers at once.
+type inboundFilter struct {
+	config     InboundConfig
+	mux        sync.Mutex
+	buckets    map[string]*tokenBucket
+	violations tokenBucket
+}
+
+func newInboundFilter(config InboundConfig) *inboundFilter {
+	return &inboundFilter{
+		config:  config,
+		buckets: make(map[string]*tokenBucket),
+	}
+}
+
+// Returns why the packet should be thrown away, and what sort of offence it was, if it should
+func (f *inboundFilter) check(packet *packets.Packet, now time.Time) (server.Offence, error) {
+	if err := validateInbound(packet.Msg); err != nil {
+		return server.OffenceMalformed, err
+	}
+
+	messageType := packets.MessageType(packet)
+
+	f.mux.Lock()
+	defer f.mux.Unlock()
+
+	if !f.bucket(messageType).take(f.budget(messageType), now) {
+		return server.OffenceFlood, fmt.Errorf("too many %s messages", messageType)
+	}
+	return "", nil
+}
+
+// Counts a thrown away packet against the client, returning whether it's broken the rules
+// often enough to be kicked
+func (f *inboundFilter) violation(now time.Time) bool {
+	f.mux.Lock()
+	defer f.mux.Unlock()
+
+	return !f.violations.take(f.config.Violations, now)
+}
+
+func (f *inboundFilter) bucket(messageType string) *tokenBucket {
+	bucket, exists := f.buckets[messageType]
+	if !exists {
+		bucket = &tokenBucket{}
+		f.buckets[messageType] = bucket
+	}
+	return bucket
+}
+
+func (f *inboundFilter) budget(messageType string) RateBudget {
+	if budget, exists := f.config.Budgets[messageType]; exists {
+		return budget
+	}
+	return f.config.DefaultBudget
+}
+
+// Checks the things no state should ever have to, like whether a number is a number, and
+// that the client isn't sending messages only the server sends
+func validateInbound(message packets.Msg) error {
+	switch message := message.(type) {
+	case nil:
+		return errors.New("empty packet")
+	case *packets.Packet_PlayerDirection:
+		if !isFinite(message.PlayerDirection.Direction) {
+			return errors.New("direction is not a finite number")
+		}
+		if !isFinite(message.PlayerDirection.ClientTime) {
+			return errors.New("client time is not a finite number")
+		}
+	case *packets.Packet_Chat:
+		return validateText("chat message", message.Chat.Msg, maxChatLength)
+	case *packets.Packet_LoginRequest:
+		return validateCredentials(message.LoginRequest.Username, message.LoginRequest.Password)
+	case *packets.Packet_RegisterRequest:
+		return validateCredentials(message.RegisterRequest.Username, message.RegisterRequest.Password)
+	case *packets.Packet_SearchHiscore:
+		return validateText("hiscore search", message.SearchHiscore.Name, maxUsernameLength)
+	case *packets.Packet_Disconnect:
+		return validateText("disconnect reason", message.Disconnect.Reason, maxDisconnectLength)
+	case *packets.Packet_ReplayRequest:
+		if speed := message.ReplayRequest.Speed; !isFinite(speed) || speed < 0 || speed > maxReplaySpeed {
+			return fmt.Errorf("replay speed must be between 0 and %d", maxReplaySpeed)
+		}
+		return validateText("replay name", message.ReplayRequest.Name, maxReplayNameLength)
+	case *packets.Packet_Id, *packets.Packet_OkResponse, *packets.Packet_DenyResponse, *packets.Packet_Player,
+		*packets.Packet_Spore, *packets.Packet_SporeBatch, *packets.Packet_Hiscore, *packets.Packet_HiscoreBoard,
+		*packets.Packet_Death, *packets.Packet_PowerUp:
+		return fmt.Errorf("clients can't send %T", message)
+	}
+	return nil
+}
+
+func validateCredentials(username, password string) error {
+	if err := validateText("username", username, maxUsernameLength); err != nil {
+		return err
+	}
+	return validateText("password", password, maxPasswordLength)
+}
+
+func validateText(what, text string, maxLength int) error {
+	if len(text) > maxLength {
+		return fmt.Errorf("%s is longer than %d bytes", what, maxLength)
+	}
+	if !utf8.ValidString(text) {
+		return fmt.Errorf("%s is not valid UTF-8", what)
+	}
+	return nil
+}
+
+func isFinite(x float64) bool {
+	return !math.IsNaN(x) && !math.IsInf(x, 0)
+}
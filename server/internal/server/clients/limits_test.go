@@ -0,0 +1,74 @@
+package clients_test
+
+import (
+	"context"
+	"database/sql"
+	"net"
+	"net/netip"
+	"server/internal/server"
+	"server/internal/server/servertest"
+	"testing"
+	"time"
+)
+
+// One end of an in-memory pipe that looks like it came in from a real address
+type addrConn struct {
+	net.Conn
+	remoteAddr net.Addr
+}
+
+func (c *addrConn) RemoteAddr() net.Addr {
+	return c.remoteAddr
+}
+
+// Serves a connection from the address, returning the client's end of it
+func connectFrom(t *testing.T, hub *server.Hub, ip string) net.Conn {
+	t.Helper()
+
+	remote, local := net.Pipe()
+	t.Cleanup(func() { remote.Close() })
+	addr := net.TCPAddrFromAddrPort(netip.AddrPortFrom(netip.MustParseAddr(ip), 1234))
+	go hub.ServeConn(tcpFactory, &addrConn{Conn: local, remoteAddr: addr})
+	return remote
+}
+
+func TestConnectionLimitsComeBeforeBans(t *testing.T) {
+	dbPool, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Error opening database: %v", err)
+	}
+	dbPool.SetMaxOpenConns(1)
+
+	hub := server.NewHubWithDb(dbPool)
+	hub.Limits = server.ConnectionLimits{MaxClients: 2, MaxClientsPerIP: 1}
+	go hub.Run()
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), servertest.Timeout)
+		defer cancel()
+		hub.Shutdown(ctx, "Test is over")
+	})
+
+	// Once there's a client, the hub has set up the database
+	if _, err := readPacket(connectFrom(t, hub, "10.0.0.1")); err != nil {
+		t.Fatalf("Expected an id: %v", err)
+	}
+	if _, err := hub.BanIP(context.Background(), netip.MustParsePrefix("10.0.0.2/32"), "testing", time.Time{}); err != nil {
+		t.Fatalf("Error banning address: %v", err)
+	}
+
+	// Banned connections give back the slots they were reserved, or there'd be no room for this
+	for range 3 {
+		expectClosed(t, connectFrom(t, hub, "10.0.0.2"))
+	}
+	if _, err := readPacket(connectFrom(t, hub, "10.0.0.3")); err != nil {
+		t.Fatalf("Expected an id: %v", err)
+	}
+
+	// With the database tied up, going to it for the bans would hold this connection up
+	conn, err := dbPool.Conn(context.Background())
+	if err != nil {
+		t.Fatalf("Error taking the database connection: %v", err)
+	}
+	defer conn.Close()
+	expectClosed(t, connectFrom(t, hub, "10.0.0.1"))
+}
@@ -0,0 +1,106 @@
+package clients
+
+import (
+	"errors"
+	"log/slog"
+	"server/internal/server"
+	"server/internal/server/states"
+	"server/pkg/packets"
+	"sync"
+	"time"
+)
+
+var ErrLoopbackTimeout = errors.New("timed out waiting for a packet")
+
+// A client that lives entirely in the process, for driving the state machine from tests and
+// tools without a real connection. Everything the server sends it is recorded, and inbound
+// packets are injected as if they'd been read off the wire.
+type LoopbackClient struct {
+	clientCore
+
+	sentMux sync.Mutex
+	sent    []*packets.Packet
+	// Index of the next packet Next returns
+	cursor int
+	// Closed and replaced whenever a packet is sent, to wake up anyone waiting in Next
+	sentSignal chan struct{}
+}
+
+func NewLoopbackClient(hub *server.Hub) *LoopbackClient {
+	c := &LoopbackClient{
+		sentSignal: make(chan struct{}),
+	}
+	c.initCore(c, hub, slog.Default().With("transport", "loopback"))
+	return c
+}
+
+func (c *LoopbackClient) Initialize(id uint64) {
+	c.start(id, &states.Connected{})
+}
+
+func (c *LoopbackClient) SocketSend(message packets.Msg) {
+	c.SocketSendAs(message, c.Id())
+}
+
+func (c *LoopbackClient) SocketSendAs(message packets.Msg, senderId uint64) {
+	c.sentMux.Lock()
+	defer c.sentMux.Unlock()
+
+	c.sent = append(c.sent, &packets.Packet{SenderId: senderId, Msg: message})
+	close(c.sentSignal)
+	c.sentSignal = make(chan struct{})
+}
+
+// Hands the message to the client as though it had sent it, going through the same checks as
+// messages from a real connection
+func (c *LoopbackClient) Inject(message packets.Msg) {
+	c.touch()
+	c.receive(&packets.Packet{Msg: message})
+}
+
+// Returns every packet sent to the client so far
+func (c *LoopbackClient) Sent() []*packets.Packet {
+	c.sentMux.Lock()
+	defer c.sentMux.Unlock()
+
+	return append([]*packets.Packet(nil), c.sent...)
+}
+
+// Returns the packets sent to the client one at a time, in order, waiting up to the timeout
+// for the next one to arrive
+func (c *LoopbackClient) Next(timeout time.Duration) (*packets.Packet, error) {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	for {
+		c.sentMux.Lock()
+		if c.cursor < len(c.sent) {
+			packet := c.sent[c.cursor]
+			c.cursor++
+			c.sentMux.Unlock()
+			return packet, nil
+		}
+		signal := c.sentSignal
+		c.sentMux.Unlock()
+
+		select {
+		case <-signal:
+		case <-timer.C:
+			return nil, ErrLoopbackTimeout
+		}
+	}
+}
+
+// Closed once the client has shut down
+func (c *LoopbackClient) Done() <-chan struct{} {
+	return c.done
+}
+
+// There's nothing to read from or write to, so the pumps just wait for the client to close
+func (c *LoopbackClient) ReadPump() {
+	<-c.done
+}
+
+func (c *LoopbackClient) WritePump() {
+	<-c.done
+}
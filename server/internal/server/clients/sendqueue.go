@@ -0,0 +1,95 @@
+package clients
+
+import (
+	"server/internal/server/metrics"
+	"server/pkg/packets"
+	"sync"
+)
+
+// Packets waiting for a transport's write pump. Player updates that don't fit are kept aside,
+// only the latest per player, with a signal for the write pump to send them.
+type sendQueue struct {
+	packets chan *packets.Packet
+
+	pendingMux     sync.Mutex
+	pendingPlayers map[uint64]*packets.Packet
+	pendingSignal  chan struct{}
+}
+
+func newSendQueue(size int) *sendQueue {
+	return &sendQueue{
+		packets:        make(chan *packets.Packet, size),
+		pendingPlayers: make(map[uint64]*packets.Packet),
+		pendingSignal:  make(chan struct{}, 1),
+	}
+}
+
+// Hands the player updates kept aside so far to the write pump
+func (q *sendQueue) takePending() map[uint64]*packets.Packet {
+	q.pendingMux.Lock()
+	defer q.pendingMux.Unlock()
+
+	pending := q.pendingPlayers
+	q.pendingPlayers = make(map[uint64]*packets.Packet, len(pending))
+	return pending
+}
+
+// Throws away the update kept aside for the player, if any
+func (q *sendQueue) dropPending(playerId uint64) {
+	q.pendingMux.Lock()
+	defer q.pendingMux.Unlock()
+
+	delete(q.pendingPlayers, playerId)
+}
+
+// The player the packet says is gone, if it says so
+func departedPlayer(packet *packets.Packet) (uint64, bool) {
+	switch message := packet.Msg.(type) {
+	case *packets.Packet_Disconnect:
+		return packet.SenderId, true
+	case *packets.Packet_PlayerConsumed:
+		return message.PlayerConsumed.PlayerId, true
+	}
+	return 0, false
+}
+
+// Queues the packet for the write pump without waiting, leaving it to the client's overflow
+// policy if there's no room
+func (c *clientCore) enqueue(q *sendQueue, packet *packets.Packet) {
+	// The write pump may well get to kept aside updates after this packet, and an update for a
+	// player that's already gone would bring them back
+	if playerId, ok := departedPlayer(packet); ok {
+		q.dropPending(playerId)
+	}
+
+	select {
+	case q.packets <- packet:
+		metrics.PacketsOut.Inc(packets.MessageType(packet))
+		return
+	default:
+	}
+
+	if c.overflow(packet.Msg) != OverflowCoalesce {
+		return
+	}
+
+	playerMessage, ok := packet.Msg.(*packets.Packet_Player)
+	if !ok {
+		c.hub.Drops.Dropped.Add(1)
+		return
+	}
+
+	q.pendingMux.Lock()
+	if _, exists := q.pendingPlayers[playerMessage.Player.Id]; exists {
+		c.hub.Drops.Coalesced.Add(1)
+	} else {
+		metrics.PacketsOut.Inc(packets.MessageType(packet))
+	}
+	q.pendingPlayers[playerMessage.Player.Id] = packet
+	q.pendingMux.Unlock()
+
+	select {
+	case q.pendingSignal <- struct{}{}:
+	default:
+	}
+}
@@ -0,0 +1,40 @@
+package clients
+
+import (
+	"server/internal/server"
+	"server/internal/server/objects"
+	"server/pkg/packets"
+	"testing"
+	"time"
+)
+
+func TestEnqueueDropsPendingUpdatesForDepartedPlayers(t *testing.T) {
+	c := &clientCore{hub: &server.Hub{}, overflowPolicy: DefaultOverflowPolicy}
+	q := newSendQueue(1)
+
+	// Fill the queue so the player updates after it have to be kept aside
+	c.enqueue(q, &packets.Packet{Msg: packets.NewChat("filler")})
+	for _, playerId := range []uint64{7, 8, 9} {
+		c.enqueue(q, &packets.Packet{SenderId: playerId, Msg: packets.NewPlayer(playerId, &objects.Player{}, time.Time{})})
+	}
+	<-q.packets
+
+	c.enqueue(q, &packets.Packet{SenderId: 8, Msg: &packets.Packet_PlayerConsumed{
+		PlayerConsumed: &packets.PlayerConsumedMessage{PlayerId: 7},
+	}})
+	<-q.packets
+	c.enqueue(q, &packets.Packet{SenderId: 9, Msg: packets.NewDisconnect("left")})
+
+	pending := q.takePending()
+	if len(pending) != 1 || pending[8] == nil {
+		t.Fatalf("Kept aside updates for %v, want only player 8", keys(pending))
+	}
+}
+
+func keys(pending map[uint64]*packets.Packet) []uint64 {
+	var ids []uint64
+	for id := range pending {
+		ids = append(ids, id)
+	}
+	return ids
+}
@@ -0,0 +1,67 @@
+package clients
+
+import (
+	"server/internal/server"
+	"server/internal/server/metrics"
+	"sync"
+	"time"
+)
+
+// The suspicion score of the player the client is logged in as. Offences are reported both
+// from the event loop and the transport's readers, hence the lock.
+type suspicion struct {
+	mux sync.Mutex
+	// Zero while nobody's logged in, or for bots, whose scores aren't kept
+	playerId int64
+	score    server.SuspicionScore
+	kicked   bool
+}
+
+func (s *suspicion) reset(playerId int64, score server.SuspicionScore) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	s.playerId = playerId
+	s.score = score
+	s.kicked = false
+}
+
+// Counts the offence against the player, returning the player's id and new score, and whether
+// it's the first time the score has reached the threshold
+func (s *suspicion) add(offence server.Offence, now time.Time, config server.AntiCheatConfig) (int64, server.SuspicionScore, bool) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	if s.playerId == 0 {
+		return 0, s.score, false
+	}
+
+	s.score = s.score.Add(offence, now, config.HalfLife)
+	kick := config.KickThreshold > 0 && s.score.Score >= config.KickThreshold && !s.kicked
+	if kick {
+		s.kicked = true
+	}
+	return s.playerId, s.score, kick
+}
+
+// Nothing is held against clients nobody has logged in on, since there's no player to hold
+// it against, but those are still kicked for too many bad messages by the inbound filter
+func (c *clientCore) Suspect(offence server.Offence, err error) {
+	metrics.Offences.Inc(string(offence))
+
+	playerId, score, kick := c.suspicion.add(offence, c.hub.Sim.Now(), c.hub.AntiCheat)
+	if playerId == 0 {
+		return
+	}
+	c.Logger().Debug("Suspicion score went up", "offence", offence, "score", score.Score, "error", err)
+
+	// Saved by the hub in the background, since this may be on a reader shared by every client
+	if !c.hub.ReportOffence(playerId, offence, score) {
+		c.Logger().Warn("Too many offences waiting to be saved, not saving this one", "offence", offence)
+	}
+
+	if kick {
+		c.Logger().Warn("Kicking suspected cheater", "offence", offence, "score", score.Score, "threshold", c.hub.AntiCheat.KickThreshold)
+		c.kick("Kicked for suspicious behaviour")
+	}
+}
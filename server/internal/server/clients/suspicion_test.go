@@ -0,0 +1,65 @@
+package clients_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"server/internal/server"
+	"server/internal/server/servertest"
+	"testing"
+	"time"
+)
+
+func TestSuspectDoesNotWaitOnTheDatabase(t *testing.T) {
+	dbPool, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Error opening database: %v", err)
+	}
+	dbPool.SetMaxOpenConns(1)
+
+	hub := server.NewHubWithDb(dbPool)
+	go hub.Run()
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), servertest.Timeout)
+		defer cancel()
+		hub.Shutdown(ctx, "Test is over")
+	})
+
+	srv := &servertest.Server{Hub: hub}
+	client := srv.Join(t, "player", "secret")
+
+	// Hold the only connection, so anything that goes to the database has to wait for it
+	conn, err := dbPool.Conn(context.Background())
+	if err != nil {
+		t.Fatalf("Error taking the database connection: %v", err)
+	}
+
+	suspected := make(chan struct{})
+	go func() {
+		client.Suspect(server.OffenceMalformed, errors.New("sent something odd"))
+		close(suspected)
+	}()
+	select {
+	case <-suspected:
+	case <-time.After(servertest.Timeout):
+		t.Fatal("Suspect waited on the database")
+	}
+
+	conn.Close()
+
+	// Saved once the database is free again. Players are numbered from 1.
+	deadline := time.Now().Add(servertest.Timeout)
+	for {
+		score, err := hub.LoadSuspicion(context.Background(), 1)
+		if err != nil {
+			t.Fatalf("Error loading suspicion score: %v", err)
+		}
+		if score.Score == server.OffenceMalformed.Weight() {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Suspicion score saved as %v, want %v", score.Score, server.OffenceMalformed.Weight())
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
@@ -0,0 +1,260 @@
+package clients
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"server/internal/server"
+	"server/internal/server/states"
+	"server/pkg/packets"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// Every message on a TCP connection, both ways, is a protobuf packet prefixed with its size
+// as a 4-byte big-endian integer
+const frameHeaderSize = 4
+
+type TCPConfig struct {
+	// How long a single write can take before giving up on the connection
+	WriteWait time.Duration
+	// Clients that aren't playing or spectating are disconnected after not sending anything for this long
+	IdleTimeout time.Duration
+	// How often to check the client is idle, and to probe the connection to see it's still there
+	KeepAlive time.Duration
+	// Largest message the client may send, anything bigger closes the connection
+	MaxMessageSize int
+	// Rate budgets and how much bad behaviour to put up with before kicking the client
+	Inbound InboundConfig
+}
+
+var DefaultTCPConfig = TCPConfig{
+	WriteWait:   10 * time.Second,
+	IdleTimeout: 5 * time.Minute,
+	KeepAlive:   20 * time.Second,
+
+	MaxMessageSize: 4096,
+	Inbound:        DefaultInboundConfig,
+}
+
+// A client on a raw TCP connection, for native clients and tools that don't need WebSocket.
+// If there's a datagram server, the client is also given a token to send and receive position
+// updates over UDP.
+type TCPClient struct {
+	clientCore
+	conn      net.Conn
+	queue     *sendQueue
+	config    TCPConfig
+	datagrams *DatagramServer
+	token     uint64
+}
+
+// Returns a function to pass to Hub.ServeConn that creates clients with the given config. The
+// datagram server can be nil, in which case everything goes over TCP.
+func NewTCPClientFactory(config TCPConfig, datagrams *DatagramServer) func(*server.Hub, net.Conn) (server.ClientInterfacer, error) {
+	return func(hub *server.Hub, conn net.Conn) (server.ClientInterfacer, error) {
+		return newTCPClient(config, datagrams, hub, conn)
+	}
+}
+
+func newTCPClient(config TCPConfig, datagrams *DatagramServer, hub *server.Hub, conn net.Conn) (server.ClientInterfacer, error) {
+	if tcpConn, ok := conn.(*net.TCPConn); ok {
+		if err := tcpConn.SetKeepAlivePeriod(config.KeepAlive); err != nil {
+			return nil, err
+		}
+		if err := tcpConn.SetKeepAlive(true); err != nil {
+			return nil, err
+		}
+	}
+
+	c := &TCPClient{
+		conn:      conn,
+		queue:     newSendQueue(256),
+		config:    config,
+		datagrams: datagrams,
+	}
+	c.initCore(c, hub, slog.Default().With("transport", "tcp", "remote_addr", conn.RemoteAddr().String()))
+	c.inbound = newInboundFilter(config.Inbound)
+
+	return c, nil
+}
+
+func (c *TCPClient) Initialize(id uint64) {
+	// Datagrams can arrive as soon as the client is bound, so it needs its id and token by then
+	c.assignId(id)
+	if c.datagrams != nil {
+		c.token = c.datagrams.reserve()
+		c.datagrams.bind(c.token, c)
+		c.SocketSend(packets.NewDatagramToken(c.token))
+	}
+
+	c.enter(&states.Connected{})
+}
+
+func (c *TCPClient) SocketSend(message packets.Msg) {
+	c.SocketSendAs(message, c.Id())
+}
+
+func (c *TCPClient) SocketSendAs(message packets.Msg, senderId uint64) {
+	packet := &packets.Packet{SenderId: senderId, Msg: message}
+
+	// Each player update supersedes the last, so losing one over UDP is no worse than coalescing it
+	if _, ok := message.(*packets.Packet_Player); ok && c.datagrams.send(c.token, packet) {
+		return
+	}
+
+	c.enqueue(c.queue, packet)
+}
+
+func (c *TCPClient) SocketSendReliably(message packets.Msg, senderId uint64) {
+	c.enqueue(c.queue, &packets.Packet{SenderId: senderId, Msg: message})
+}
+
+// Datagrams can be lost, duplicated or arrive out of order, so only messages that the next
+// one supersedes are accepted over UDP. If the client is behind, they're dropped rather than
+// waiting for it.
+func (c *TCPClient) receiveDatagram(packet *packets.Packet) {
+	if _, ok := packet.Msg.(*packets.Packet_PlayerDirection); !ok {
+		c.reject(packet, server.OffenceMalformed, fmt.Errorf("%s messages can't be sent as datagrams", packets.MessageType(packet)))
+		return
+	}
+
+	c.touch()
+	c.receiveWithoutWaiting(packet)
+}
+
+// Closes the connection outright, for when the client never got as far as its write pump
+func (c *TCPClient) CloseConn() error {
+	return c.conn.Close()
+}
+
+func (c *TCPClient) ReadPump() {
+	defer func() {
+		c.Logger().Debug("Closing read pump")
+		c.Close("read pump closed")
+	}()
+
+	reader := bufio.NewReader(c.conn)
+	header := make([]byte, frameHeaderSize)
+
+	for {
+		if _, err := io.ReadFull(reader, header); err != nil {
+			if !errors.Is(err, io.EOF) && !errors.Is(err, net.ErrClosed) {
+				c.Logger().Error("Error reading from connection", "error", err)
+			}
+			break
+		}
+
+		size := binary.BigEndian.Uint32(header)
+		if size > uint32(c.config.MaxMessageSize) {
+			c.Logger().Warn("Message too big, closing client", "size", size, "max_size", c.config.MaxMessageSize)
+			break
+		}
+
+		data := make([]byte, size)
+		if _, err := io.ReadFull(reader, data); err != nil {
+			c.Logger().Error("Error reading from connection", "error", err)
+			break
+		}
+		c.touch()
+
+		packet := &packets.Packet{}
+		if err := proto.Unmarshal(data, packet); err != nil {
+			c.Logger().Warn("Error unmarshalling data", "error", err)
+			continue
+		}
+
+		c.receive(packet)
+	}
+}
+
+func (c *TCPClient) WritePump() {
+	writer := bufio.NewWriter(c.conn)
+
+	defer func() {
+		c.Logger().Debug("Closing write pump")
+		c.datagrams.unbind(c.token)
+		c.conn.Close()
+		c.Close("write pump closed")
+	}()
+
+	idleTicker := time.NewTicker(c.config.KeepAlive)
+	defer idleTicker.Stop()
+
+	for {
+		select {
+		case <-idleTicker.C:
+			c.Post(func() { c.checkIdle(c.config.IdleTimeout) })
+		case packet := <-c.queue.packets:
+			if err := c.writePacket(writer, packet); err != nil {
+				return
+			}
+		case <-c.queue.pendingSignal:
+			for _, packet := range c.queue.takePending() {
+				if err := c.writePacket(writer, packet); err != nil {
+					return
+				}
+			}
+		case <-c.done:
+			c.flush(writer)
+			return
+		}
+
+		// Batch up whatever is already waiting into as few writes as possible
+		if len(c.queue.packets) == 0 {
+			if err := c.flushWriter(writer); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// Sends whatever was queued by the time the client closed, like the reason it was disconnected
+func (c *TCPClient) flush(writer *bufio.Writer) {
+	for {
+		select {
+		case packet := <-c.queue.packets:
+			if err := c.writePacket(writer, packet); err != nil {
+				return
+			}
+		default:
+			c.flushWriter(writer)
+			return
+		}
+	}
+}
+
+func (c *TCPClient) flushWriter(writer *bufio.Writer) error {
+	if writer.Buffered() == 0 {
+		return nil
+	}
+
+	c.conn.SetWriteDeadline(time.Now().Add(c.config.WriteWait))
+	if err := writer.Flush(); err != nil {
+		c.Logger().Error("Error flushing packets, closing client", "error", err)
+		return err
+	}
+	return nil
+}
+
+// Only returns an error when the connection can't be written to anymore
+func (c *TCPClient) writePacket(writer *bufio.Writer, packet *packets.Packet) error {
+	data, err := proto.Marshal(packet)
+	if err != nil {
+		c.Logger().Error("Error marshalling packet", "message_type", packets.MessageType(packet), "error", err)
+		return nil
+	}
+
+	c.conn.SetWriteDeadline(time.Now().Add(c.config.WriteWait))
+	writer.Write(binary.BigEndian.AppendUint32(nil, uint32(len(data))))
+	if _, err := writer.Write(data); err != nil {
+		c.Logger().Error("Error writing packet, closing client", "message_type", packets.MessageType(packet), "error", err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,279 @@
+package clients_test
+
+import (
+	"context"
+	"database/sql"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"runtime"
+	"server/internal/server"
+	"server/internal/server/clients"
+	"server/internal/server/servertest"
+	"server/pkg/packets"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+)
+
+var tcpFactory = clients.NewTCPClientFactory(clients.DefaultTCPConfig, nil)
+
+// Reads one size-prefixed packet off the client's end of the connection
+func readPacket(conn net.Conn) (*packets.Packet, error) {
+	conn.SetReadDeadline(time.Now().Add(servertest.Timeout))
+
+	header := make([]byte, 4)
+	if _, err := io.ReadFull(conn, header); err != nil {
+		return nil, err
+	}
+	data := make([]byte, binary.BigEndian.Uint32(header))
+	if _, err := io.ReadFull(conn, data); err != nil {
+		return nil, err
+	}
+
+	packet := &packets.Packet{}
+	return packet, proto.Unmarshal(data, packet)
+}
+
+// Connects a TCP client over an in-memory pipe, returning the client's end of it and the
+// client the hub made for it
+func connectTCP(t *testing.T, hub *server.Hub) (net.Conn, server.ClientInterfacer) {
+	t.Helper()
+
+	remote, local := net.Pipe()
+	t.Cleanup(func() { remote.Close() })
+	go hub.ServeConn(tcpFactory, local)
+
+	packet, err := readPacket(remote)
+	if err != nil {
+		t.Fatalf("Expected an id: %v", err)
+	}
+	idMessage, ok := packet.Msg.(*packets.Packet_Id)
+	if !ok {
+		t.Fatalf("Expected an id, got %T", packet.Msg)
+	}
+
+	client, exists := hub.Clients.Get(idMessage.Id.Id)
+	if !exists {
+		t.Fatalf("Client %d isn't registered", idMessage.Id.Id)
+	}
+	return remote, client
+}
+
+// Writes one size-prefixed packet to the server's end of the connection
+func writePacket(t *testing.T, conn net.Conn, packet *packets.Packet) {
+	t.Helper()
+
+	data, err := proto.Marshal(packet)
+	if err != nil {
+		t.Fatalf("Error marshalling packet: %v", err)
+	}
+	conn.SetWriteDeadline(time.Now().Add(servertest.Timeout))
+	if _, err := conn.Write(append(binary.BigEndian.AppendUint32(nil, uint32(len(data))), data...)); err != nil {
+		t.Fatalf("Error writing packet: %v", err)
+	}
+}
+
+// Reads until the connection is closed, failing the test if it stays open
+func expectClosed(t *testing.T, conn net.Conn) {
+	t.Helper()
+
+	for {
+		_, err := readPacket(conn)
+		if err == nil {
+			continue
+		}
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrClosedPipe) {
+			return
+		}
+		t.Fatalf("Expected the connection to be closed: %v", err)
+	}
+}
+
+func expectUnregistered(t *testing.T, hub *server.Hub, clientId uint64) {
+	t.Helper()
+
+	deadline := time.Now().Add(servertest.Timeout)
+	for {
+		if _, exists := hub.Clients.Get(clientId); !exists {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Client %d is still registered with the hub", clientId)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestTCPClientConcurrentClose(t *testing.T) {
+	srv := servertest.NewServer(t)
+	// Once a client has been registered, the hub's own goroutines are all running
+	srv.Connect(t)
+	before := runtime.NumGoroutine()
+	remote, client := connectTCP(t, srv.Hub)
+
+	var wg sync.WaitGroup
+	for range 16 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			client.Close("closed concurrently")
+		}()
+		// Keep the write pump busy while it's being closed
+		go func() {
+			defer wg.Done()
+			client.SocketSend(packets.NewChat("still here"))
+		}()
+	}
+
+	expectClosed(t, remote)
+	wg.Wait()
+	expectUnregistered(t, srv.Hub, client.Id())
+	expectNoLeakedGoroutines(t, before)
+}
+
+// A connection that can be read from but never written to
+type unwritableConn struct {
+	net.Conn
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+func (c *unwritableConn) Write([]byte) (int, error) {
+	return 0, errors.New("connection reset by peer")
+}
+
+func (c *unwritableConn) Close() error {
+	c.closeOnce.Do(func() { close(c.closed) })
+	return c.Conn.Close()
+}
+
+func TestTCPClientWriteError(t *testing.T) {
+	srv := servertest.NewServer(t)
+
+	remote, local := net.Pipe()
+	defer remote.Close()
+	conn := &unwritableConn{Conn: local, closed: make(chan struct{})}
+
+	srv.Hub.ServeConn(tcpFactory, conn)
+
+	select {
+	case <-conn.closed:
+	case <-time.After(servertest.Timeout):
+		t.Fatal("Connection wasn't closed after failing to write")
+	}
+
+	deadline := time.Now().Add(servertest.Timeout)
+	for srv.Hub.Clients.Len() > 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("Client is still registered with the hub")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestHubShutdownClosesClients(t *testing.T) {
+	dbPool, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Error opening database: %v", err)
+	}
+	dbPool.SetMaxOpenConns(1)
+
+	hub := server.NewHubWithDb(dbPool)
+	go hub.Run()
+
+	remote, client := connectTCP(t, hub)
+
+	ctx, cancel := context.WithTimeout(context.Background(), servertest.Timeout)
+	defer cancel()
+	if err := hub.Shutdown(ctx, "Test is over"); err != nil {
+		t.Fatalf("Error shutting down hub: %v", err)
+	}
+
+	packet, err := readPacket(remote)
+	if err != nil {
+		t.Fatalf("Expected to be told why the client was disconnected: %v", err)
+	}
+	if _, ok := packet.Msg.(*packets.Packet_Disconnect); !ok {
+		t.Fatalf("Expected a disconnect, got %T", packet.Msg)
+	}
+	expectClosed(t, remote)
+
+	if _, exists := hub.Clients.Get(client.Id()); exists {
+		t.Errorf("Client %d is still registered with the hub", client.Id())
+	}
+
+	// Clients registered after the hub stops have no write pump to close their connection
+	lateRemote, lateLocal := net.Pipe()
+	defer lateRemote.Close()
+	lateClient, err := tcpFactory(hub, lateLocal)
+	if err != nil {
+		t.Fatalf("Error creating client: %v", err)
+	}
+	hub.Register(lateClient)
+	expectClosed(t, lateRemote)
+}
+
+func TestTCPClientSendsSnapshotsOverTCP(t *testing.T) {
+	srv := servertest.NewServer(t)
+	srv.Join(t, "player", "secret")
+
+	datagrams, err := clients.ListenDatagrams("127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Error listening for datagrams: %v", err)
+	}
+	defer datagrams.Close()
+	go datagrams.Serve()
+
+	remote, local := net.Pipe()
+	defer remote.Close()
+	go srv.Hub.ServeConn(clients.NewTCPClientFactory(clients.DefaultTCPConfig, datagrams), local)
+
+	packet, err := readPacket(remote)
+	if err != nil {
+		t.Fatalf("Expected a datagram token: %v", err)
+	}
+	tokenMessage, ok := packet.Msg.(*packets.Packet_DatagramToken)
+	if !ok {
+		t.Fatalf("Expected a datagram token, got %T", packet.Msg)
+	}
+
+	// Once the client has sent a datagram, player updates can go back to where it came from
+	udpConn, err := net.DialUDP("udp", nil, datagrams.Addr().(*net.UDPAddr))
+	if err != nil {
+		t.Fatalf("Error dialing datagram server: %v", err)
+	}
+	defer udpConn.Close()
+	data, _ := proto.Marshal(&packets.Packet{Msg: &packets.Packet_PlayerDirection{
+		PlayerDirection: &packets.PlayerDirectionMessage{},
+	}})
+	token := tokenMessage.DatagramToken.Token
+
+	// Datagrams can be lost, so keep sending until one gets there
+	deadline := time.Now().Add(servertest.Timeout)
+	for datagrams.BoundAddr(token) == nil {
+		if time.Now().After(deadline) {
+			t.Fatal("Datagram server never saw the client's datagram")
+		}
+		udpConn.Write(append(binary.BigEndian.AppendUint64(nil, token), data...))
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	writePacket(t, remote, &packets.Packet{Msg: &packets.Packet_SpectateRequest{
+		SpectateRequest: &packets.SpectateRequestMessage{},
+	}})
+
+	// The players already in game must arrive reliably, or the spectator may never see them
+	for {
+		packet, err := readPacket(remote)
+		if err != nil {
+			t.Fatalf("Expected the player in game over TCP: %v", err)
+		}
+		if _, ok := packet.Msg.(*packets.Packet_Player); ok {
+			return
+		}
+	}
+}
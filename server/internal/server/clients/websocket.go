@@ -1,179 +1,250 @@
 package clients
 
 import (
-	"fmt"
-	"log"
+	"encoding/binary"
+	"log/slog"
 	"net/http"
 	"server/internal/server"
 	"server/internal/server/states"
 	"server/pkg/packets"
+	"strings"
+	"time"
 
 	"github.com/gorilla/websocket"
 	"google.golang.org/protobuf/proto"
 )
 
+type WebSocketConfig struct {
+	// How often to ping the client to check it's still there and measure its round trip time
+	PingInterval time.Duration
+	// How long to wait for anything, including a pong, before giving up on the connection
+	PongWait time.Duration
+	// How long a single write can take before giving up on the connection
+	WriteWait time.Duration
+	// Clients that aren't playing or spectating are disconnected after not sending anything for this long
+	IdleTimeout time.Duration
+	// Browser origins allowed to connect, where "*" allows any. Requests without an origin,
+	// like those from native clients, are always allowed. Leave empty to allow any origin.
+	AllowedOrigins []string
+	// Largest message the client may send, anything bigger closes the connection
+	MaxMessageSize int64
+	// Rate budgets and how much bad behaviour to put up with before kicking the client
+	Inbound InboundConfig
+}
+
+var DefaultWebSocketConfig = WebSocketConfig{
+	PingInterval: 20 * time.Second,
+	PongWait:     30 * time.Second,
+	WriteWait:    10 * time.Second,
+	IdleTimeout:  5 * time.Minute,
+
+	MaxMessageSize: 4096,
+	Inbound:        DefaultInboundConfig,
+}
+
+func (config WebSocketConfig) checkOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" || len(config.AllowedOrigins) == 0 {
+		return true
+	}
+
+	for _, allowed := range config.AllowedOrigins {
+		if allowed == "*" || strings.EqualFold(allowed, origin) {
+			return true
+		}
+	}
+	return false
+}
+
 type WebSocketClient struct {
-	id       uint64
-	conn     *websocket.Conn
-	hub      *server.Hub
-	sendChan chan *packets.Packet
-	logger   *log.Logger
-	state    server.ClientStateHandler
-	dbTx     *server.DbTx
+	clientCore
+	conn   *websocket.Conn
+	queue  *sendQueue
+	config WebSocketConfig
 }
 
 func NewWebSocketClient(hub *server.Hub, w http.ResponseWriter, r *http.Request) (server.ClientInterfacer, error) {
+	return newWebSocketClient(DefaultWebSocketConfig, hub, w, r)
+}
+
+// Returns a function to pass to Hub.Serve that creates clients with the given config
+func NewWebSocketClientFactory(config WebSocketConfig) func(*server.Hub, http.ResponseWriter, *http.Request) (server.ClientInterfacer, error) {
+	return func(hub *server.Hub, w http.ResponseWriter, r *http.Request) (server.ClientInterfacer, error) {
+		return newWebSocketClient(config, hub, w, r)
+	}
+}
+
+func newWebSocketClient(config WebSocketConfig, hub *server.Hub, w http.ResponseWriter, r *http.Request) (server.ClientInterfacer, error) {
 	upgrader := websocket.Upgrader{
 		ReadBufferSize:  1024,
 		WriteBufferSize: 1024,
-		CheckOrigin:     func(_ *http.Request) bool { return true },
+		CheckOrigin:     config.checkOrigin,
 	}
 	conn, err := upgrader.Upgrade(w, r, nil)
 
 	if err != nil {
 		return nil, err
 	}
+	conn.SetReadLimit(config.MaxMessageSize)
 
 	c := &WebSocketClient{
-		hub:      hub,
-		conn:     conn,
-		sendChan: make(chan *packets.Packet, 256),
-		logger:   log.New(log.Writer(), "Client unknow: ", log.LstdFlags),
-		dbTx:     hub.NewDbTx(),
+		conn:   conn,
+		queue:  newSendQueue(256),
+		config: config,
 	}
+	c.initCore(c, hub, slog.Default().With("transport", "websocket", "remote_addr", r.RemoteAddr))
+	c.inbound = newInboundFilter(config.Inbound)
 
 	return c, nil
 }
 
-func (c *WebSocketClient) Id() uint64 {
-	return c.id
-}
-
-func (c *WebSocketClient) SetState(state server.ClientStateHandler) {
-	prevStateName := "None"
-
-	if c.state != nil {
-		prevStateName = c.state.Name()
-		c.state.OnExit()
-	}
-	newStateName := "None"
-
-	if state != nil {
-		newStateName = state.Name()
-	}
-	c.logger.Printf("Switching from state %s to %s", prevStateName, newStateName)
-
-	c.state = state
-	if c.state != nil {
-		c.state.SetClient(c)
-		c.state.OnEnter()
-	}
-}
-
 func (c *WebSocketClient) Initialize(id uint64) {
-	c.id = id
-	c.logger.SetPrefix(fmt.Sprintf("Client %d: ", id))
-	c.SetState(&states.Connected{})
-}
-
-func (c *WebSocketClient) ProcessMessage(senderId uint64, message packets.Msg) {
-	c.state.HandleMessage(senderId, message)
+	c.start(id, &states.Connected{})
 }
 
 func (c *WebSocketClient) SocketSend(message packets.Msg) {
-	c.SocketSendAs(message, c.id)
+	c.SocketSendAs(message, c.Id())
 }
 
 func (c *WebSocketClient) SocketSendAs(message packets.Msg, senderId uint64) {
-	select {
-	case c.sendChan <- &packets.Packet{SenderId: senderId, Msg: message}:
-	default:
-		c.logger.Printf("Send channel full, dropping message %T\n", message)
-	}
-}
-func (c *WebSocketClient) PassToPeer(message packets.Msg, peerId uint64) {
-	if peer, exists := c.hub.Clients.Get(peerId); exists {
-		peer.ProcessMessage(c.id, message)
-	}
+	c.enqueue(c.queue, &packets.Packet{SenderId: senderId, Msg: message})
 }
-func (c *WebSocketClient) Broadcast(message packets.Msg) {
-	c.hub.BroadcastChan <- &packets.Packet{SenderId: c.id, Msg: message}
+
+// Closes the connection outright, for when the client never got as far as its write pump
+func (c *WebSocketClient) CloseConn() error {
+	return c.conn.Close()
 }
+
 func (c *WebSocketClient) ReadPump() {
 	defer func() {
-		log.Println("Closing read pump")
+		c.Logger().Debug("Closing read pump")
 		c.Close("read pump closed")
 	}()
 
+	c.conn.SetReadDeadline(time.Now().Add(c.config.PongWait))
+	c.conn.SetPongHandler(c.handlePong)
+
 	for {
 		_, data, err := c.conn.ReadMessage()
 		if err != nil {
-			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure) {
-				c.logger.Printf("Error: %v\n", err)
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway, websocket.CloseAbnormalClosure) {
+				c.Logger().Error("Error reading from connection", "error", err)
 			}
 			break
 		}
+		c.conn.SetReadDeadline(time.Now().Add(c.config.PongWait))
+		c.touch()
+
 		packet := &packets.Packet{}
 		err = proto.Unmarshal(data, packet)
 		if err != nil {
-			c.logger.Printf("Error unmarshalling data: %v\n", err)
+			c.Logger().Warn("Error unmarshalling data", "error", err)
 			continue
 		}
 
-		if packet.SenderId == 0 {
-			packet.SenderId = c.id
-		}
-
-		c.ProcessMessage(packet.SenderId, packet.Msg)
+		c.receive(packet)
 	}
 }
 func (c *WebSocketClient) WritePump() {
 	defer func() {
-		log.Println("Closing write pump")
+		c.Logger().Debug("Closing write pump")
+		c.conn.Close()
 		c.Close("write pump closed")
 	}()
 
-	for packet := range c.sendChan {
-		writer, err := c.conn.NextWriter(websocket.BinaryMessage)
-		if err != nil {
-			c.logger.Printf("error getting writer for %T packet, closing client %v", packet.Msg, err)
+	pingTicker := time.NewTicker(c.config.PingInterval)
+	defer pingTicker.Stop()
+
+	for {
+		select {
+		case <-pingTicker.C:
+			if err := c.ping(); err != nil {
+				c.Logger().Error("Error sending ping, closing client", "error", err)
+				return
+			}
+			c.Post(func() { c.checkIdle(c.config.IdleTimeout) })
+		case packet := <-c.queue.packets:
+			if err := c.writePacket(packet); err != nil {
+				return
+			}
+		case <-c.queue.pendingSignal:
+			if err := c.writePending(); err != nil {
+				return
+			}
+		case <-c.done:
+			c.flush()
 			return
 		}
-		data, err := proto.Marshal(packet)
-		if err != nil {
-			c.logger.Printf("error marshalling %T packet, closing client %v", packet.Msg, err)
-			continue
-		}
+	}
+}
 
-		_, err = writer.Write(data)
-		if err != nil {
-			c.logger.Printf("error writing %T packet: %v", packet.Msg, err)
-			continue
-		}
-		writer.Write([]byte{'\n'})
-		if err = writer.Close(); err != nil {
-			c.logger.Printf("error closing writer for %T packet: %v", packet.Msg, err)
-			continue
-		}
+// The ping carries the time it was sent, which the client echoes back in its pong
+func (c *WebSocketClient) ping() error {
+	payload := binary.BigEndian.AppendUint64(nil, uint64(time.Now().UnixNano()))
+	return c.conn.WriteControl(websocket.PingMessage, payload, time.Now().Add(c.config.WriteWait))
+}
+
+func (c *WebSocketClient) handlePong(appData string) error {
+	c.conn.SetReadDeadline(time.Now().Add(c.config.PongWait))
 
+	if len(appData) != 8 {
+		return nil
 	}
+	sentAt := time.Unix(0, int64(binary.BigEndian.Uint64([]byte(appData))))
+	c.rtt.Store(int64(time.Since(sentAt)))
+	return nil
 }
-func (c *WebSocketClient) Close(reason string) {
-	c.logger.Printf("Closing client connection beacause: %s", reason)
-	c.Broadcast(packets.NewDisconnect(reason))
-	c.SetState(nil)
 
-	c.hub.UnregisterChan <- c
-	c.conn.Close()
-	if _, closed := <-c.sendChan; !closed {
-		close(c.sendChan)
+func (c *WebSocketClient) writePending() error {
+	for _, packet := range c.queue.takePending() {
+		if err := c.writePacket(packet); err != nil {
+			return err
+		}
 	}
+	return nil
 }
 
-func (c *WebSocketClient) SharedGameObjects() *server.SharedGameObjects {
-	return c.hub.SharedGameObjects
+// Sends whatever was queued by the time the client closed (like the reason it was
+// disconnected), then says goodbye. Anything sent to the client after this is never read.
+func (c *WebSocketClient) flush() {
+	for {
+		select {
+		case packet := <-c.queue.packets:
+			if err := c.writePacket(packet); err != nil {
+				return
+			}
+		default:
+			c.conn.SetWriteDeadline(time.Now().Add(c.config.WriteWait))
+			c.conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+			return
+		}
+	}
 }
 
-func (c *WebSocketClient) DbTx() *server.DbTx {
-	return c.dbTx
+// Only returns an error when the connection can't be written to anymore
+func (c *WebSocketClient) writePacket(packet *packets.Packet) error {
+	c.conn.SetWriteDeadline(time.Now().Add(c.config.WriteWait))
+	writer, err := c.conn.NextWriter(websocket.BinaryMessage)
+	if err != nil {
+		c.Logger().Error("Error getting writer, closing client", "message_type", packets.MessageType(packet), "error", err)
+		return err
+	}
+	data, err := proto.Marshal(packet)
+	if err != nil {
+		c.Logger().Error("Error marshalling packet", "message_type", packets.MessageType(packet), "error", err)
+		return nil
+	}
+
+	_, err = writer.Write(data)
+	if err != nil {
+		c.Logger().Error("Error writing packet, closing client", "message_type", packets.MessageType(packet), "error", err)
+		return err
+	}
+	writer.Write([]byte{'\n'})
+	// The message only goes out when the writer is closed, so this is where most write errors show up
+	if err = writer.Close(); err != nil {
+		c.Logger().Error("Error closing writer, closing client", "message_type", packets.MessageType(packet), "error", err)
+		return err
+	}
+	return nil
 }
@@ -0,0 +1,213 @@
+package clients_test
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"server/internal/server"
+	"server/internal/server/clients"
+	"server/internal/server/servertest"
+	"server/pkg/packets"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"google.golang.org/protobuf/proto"
+)
+
+// Waits for the number of goroutines to go back down to what it was before the test started
+// any, failing the test with every goroutine's stack if it doesn't
+func expectNoLeakedGoroutines(t *testing.T, before int) {
+	t.Helper()
+
+	deadline := time.Now().Add(servertest.Timeout)
+	for runtime.NumGoroutine() > before {
+		if time.Now().After(deadline) {
+			stacks := make([]byte, 1<<20)
+			stacks = stacks[:runtime.Stack(stacks, true)]
+			t.Fatalf("%d goroutines left running, want %d:\n%s", runtime.NumGoroutine(), before, stacks)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// Serves WebSocket clients made with the config over HTTP, closed when the test finishes
+func serveWebSocket(t *testing.T, hub *server.Hub, config clients.WebSocketConfig) *httptest.Server {
+	t.Helper()
+
+	factory := clients.NewWebSocketClientFactory(config)
+	httpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hub.Serve(factory, w, r)
+	}))
+	t.Cleanup(httpServer.Close)
+	return httpServer
+}
+
+func dialWebSocket(t *testing.T, httpServer *httptest.Server) *websocket.Conn {
+	t.Helper()
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(httpServer.URL, "http"), nil)
+	if err != nil {
+		t.Fatalf("Error dialing: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func readWebSocketPacket(conn *websocket.Conn) (*packets.Packet, error) {
+	conn.SetReadDeadline(time.Now().Add(servertest.Timeout))
+
+	_, data, err := conn.ReadMessage()
+	if err != nil {
+		return nil, err
+	}
+	packet := &packets.Packet{}
+	return packet, proto.Unmarshal(bytes.TrimSuffix(data, []byte{'\n'}), packet)
+}
+
+// Reads the client's id off the connection and returns the client the hub made for it
+func expectWebSocketClient(t *testing.T, hub *server.Hub, conn *websocket.Conn) server.ClientInterfacer {
+	t.Helper()
+
+	packet, err := readWebSocketPacket(conn)
+	if err != nil {
+		t.Fatalf("Expected an id: %v", err)
+	}
+	idMessage, ok := packet.Msg.(*packets.Packet_Id)
+	if !ok {
+		t.Fatalf("Expected an id, got %T", packet.Msg)
+	}
+	client, exists := hub.Clients.Get(idMessage.Id.Id)
+	if !exists {
+		t.Fatalf("Client %d isn't registered", idMessage.Id.Id)
+	}
+	return client
+}
+
+// Reads until the server closes the connection, failing the test if it stays open
+func expectWebSocketClosed(t *testing.T, conn *websocket.Conn) {
+	t.Helper()
+
+	for {
+		_, err := readWebSocketPacket(conn)
+		if err == nil {
+			continue
+		}
+		if _, closed := err.(*websocket.CloseError); closed || !isTimeout(err) {
+			return
+		}
+		t.Fatalf("Expected the connection to be closed: %v", err)
+	}
+}
+
+func isTimeout(err error) bool {
+	timeout, ok := err.(interface{ Timeout() bool })
+	return ok && timeout.Timeout()
+}
+
+func TestWebSocketClientConcurrentClose(t *testing.T) {
+	srv := servertest.NewServer(t)
+	// Once a client has been registered, the hub's own goroutines are all running
+	srv.Connect(t)
+	httpServer := serveWebSocket(t, srv.Hub, clients.DefaultWebSocketConfig)
+	before := runtime.NumGoroutine()
+
+	conn := dialWebSocket(t, httpServer)
+	client := expectWebSocketClient(t, srv.Hub, conn)
+
+	var wg sync.WaitGroup
+	for range 16 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			client.Close("closed concurrently")
+		}()
+		// Keep the write pump busy while it's being closed
+		go func() {
+			defer wg.Done()
+			client.SocketSend(packets.NewChat("still here"))
+		}()
+	}
+
+	expectWebSocketClosed(t, conn)
+	wg.Wait()
+	expectUnregistered(t, srv.Hub, client.Id())
+
+	conn.Close()
+	expectNoLeakedGoroutines(t, before)
+}
+
+func TestWebSocketClientWriteError(t *testing.T) {
+	srv := servertest.NewServer(t)
+	srv.Connect(t)
+	// Every write runs out of time before it can start
+	config := clients.DefaultWebSocketConfig
+	config.WriteWait = time.Nanosecond
+	httpServer := serveWebSocket(t, srv.Hub, config)
+	before := runtime.NumGoroutine()
+
+	conn := dialWebSocket(t, httpServer)
+	if _, err := readWebSocketPacket(conn); err == nil {
+		t.Fatal("Expected the connection to be closed without being sent anything")
+	}
+
+	deadline := time.Now().Add(servertest.Timeout)
+	// Only the loopback client is left
+	for srv.Hub.Clients.Len() > 1 {
+		if time.Now().After(deadline) {
+			t.Fatal("Client is still registered with the hub")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	conn.Close()
+	expectNoLeakedGoroutines(t, before)
+}
+
+func TestHubShutdownClosesWebSocketClients(t *testing.T) {
+	before := runtime.NumGoroutine()
+
+	dbPool, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Error opening database: %v", err)
+	}
+	dbPool.SetMaxOpenConns(1)
+
+	hub := server.NewHubWithDb(dbPool)
+	go hub.Run()
+
+	factory := clients.NewWebSocketClientFactory(clients.DefaultWebSocketConfig)
+	httpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hub.Serve(factory, w, r)
+	}))
+	conn := dialWebSocket(t, httpServer)
+	client := expectWebSocketClient(t, hub, conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), servertest.Timeout)
+	defer cancel()
+	if err := hub.Shutdown(ctx, "Test is over"); err != nil {
+		t.Fatalf("Error shutting down hub: %v", err)
+	}
+
+	packet, err := readWebSocketPacket(conn)
+	if err != nil {
+		t.Fatalf("Expected to be told why the client was disconnected: %v", err)
+	}
+	if _, ok := packet.Msg.(*packets.Packet_Disconnect); !ok {
+		t.Fatalf("Expected a disconnect, got %T", packet.Msg)
+	}
+	expectWebSocketClosed(t, conn)
+
+	if _, exists := hub.Clients.Get(client.Id()); exists {
+		t.Errorf("Client %d is still registered with the hub", client.Id())
+	}
+
+	conn.Close()
+	httpServer.Close()
+	expectNoLeakedGoroutines(t, before)
+}
@@ -44,4 +44,69 @@ select count(*) + 1 as "rank" from players
 where best_score >= (
     select best_score from players p2
     where p2.id = ?
-)
\ No newline at end of file
+);
+
+-- name: CreateUserBan :one
+insert into user_bans (
+    user_id, reason, expires_at
+) values (
+    ?, ?, ?
+)
+returning *;
+
+-- name: GetActiveUserBan :one
+select * from user_bans
+where user_id = ? and (expires_at is null or expires_at > sqlc.arg(now))
+order by expires_at is null desc, expires_at desc
+limit 1;
+
+-- name: ListActiveUserBans :many
+select user_bans.id, user_bans.user_id, users.username, user_bans.reason, user_bans.expires_at, user_bans.created_at
+from user_bans
+join users on users.id = user_bans.user_id
+where user_bans.expires_at is null or user_bans.expires_at > sqlc.arg(now)
+order by user_bans.id;
+
+-- name: DeleteUserBan :execrows
+delete from user_bans
+where id = ?;
+
+-- name: CreateIpBan :one
+insert into ip_bans (
+    cidr, reason, expires_at
+) values (
+    ?, ?, ?
+)
+returning *;
+
+-- name: ListActiveIpBans :many
+select * from ip_bans
+where expires_at is null or expires_at > sqlc.arg(now)
+order by id;
+
+-- name: DeleteIpBan :execrows
+delete from ip_bans
+where id = ?;
+
+-- name: RecordOffence :exec
+insert into suspicion_scores (
+    player_id, score, last_offence, updated_at
+) values (
+    ?, ?, ?, ?
+)
+on conflict (player_id) do update
+set score = excluded.score,
+    offences = offences + 1,
+    last_offence = excluded.last_offence,
+    updated_at = excluded.updated_at;
+
+-- name: GetSuspicionScore :one
+select * from suspicion_scores
+where player_id = ? limit 1;
+
+-- name: ListSuspicionScores :many
+select players.name, suspicion_scores.player_id, suspicion_scores.score, suspicion_scores.offences, suspicion_scores.last_offence, suspicion_scores.updated_at
+from suspicion_scores
+join players on players.id = suspicion_scores.player_id
+order by suspicion_scores.score * pow(0.5, (cast(sqlc.arg(now) as integer) - suspicion_scores.updated_at) * cast(sqlc.arg(halvings_per_second) as real)) desc
+limit sqlc.arg(limit);
@@ -12,3 +12,35 @@ CREATE TABLE IF NOT EXISTS players (
     color INTEGER NOT NULL,
     FOREIGN KEY (user_id) REFERENCES users(id)
 );
+
+CREATE TABLE IF NOT EXISTS user_bans (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL,
+    reason TEXT NOT NULL,
+    -- Unix seconds, or null for a ban that never ends
+    expires_at INTEGER,
+    created_at INTEGER NOT NULL DEFAULT (unixepoch()),
+    FOREIGN KEY (user_id) REFERENCES users(id)
+);
+
+CREATE INDEX IF NOT EXISTS user_bans_user_id ON user_bans(user_id);
+
+CREATE TABLE IF NOT EXISTS ip_bans (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    -- Single addresses are stored as a prefix covering just that address
+    cidr TEXT NOT NULL,
+    reason TEXT NOT NULL,
+    expires_at INTEGER,
+    created_at INTEGER NOT NULL DEFAULT (unixepoch())
+);
+
+CREATE TABLE IF NOT EXISTS suspicion_scores (
+    player_id INTEGER PRIMARY KEY,
+    -- Decays over time, this is what it was at updated_at
+    score REAL NOT NULL,
+    -- Every offence the player has ever committed, which doesn't decay
+    offences INTEGER NOT NULL DEFAULT 1,
+    last_offence TEXT NOT NULL,
+    updated_at INTEGER NOT NULL,
+    FOREIGN KEY (player_id) REFERENCES players(id)
+);
@@ -4,6 +4,18 @@
 
 package db
 
+import (
+	"database/sql"
+)
+
+type IpBan struct {
+	ID        int64
+	Cidr      string
+	Reason    string
+	ExpiresAt sql.NullInt64
+	CreatedAt int64
+}
+
 type Player struct {
 	ID        int64
 	UserID    int64
@@ -12,8 +24,24 @@ type Player struct {
 	Color     int64
 }
 
+type SuspicionScore struct {
+	PlayerID    int64
+	Score       float64
+	Offences    int64
+	LastOffence string
+	UpdatedAt   int64
+}
+
 type User struct {
 	ID           int64
 	Username     string
 	PasswordHash string
 }
+
+type UserBan struct {
+	ID        int64
+	UserID    int64
+	Reason    string
+	ExpiresAt sql.NullInt64
+	CreatedAt int64
+}
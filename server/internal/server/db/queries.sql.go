@@ -7,8 +7,37 @@ package db
 
 import (
 	"context"
+	"database/sql"
 )
 
+const createIpBan = `-- name: CreateIpBan :one
+insert into ip_bans (
+    cidr, reason, expires_at
+) values (
+    ?, ?, ?
+)
+returning id, cidr, reason, expires_at, created_at
+`
+
+type CreateIpBanParams struct {
+	Cidr      string
+	Reason    string
+	ExpiresAt sql.NullInt64
+}
+
+func (q *Queries) CreateIpBan(ctx context.Context, arg CreateIpBanParams) (IpBan, error) {
+	row := q.db.QueryRowContext(ctx, createIpBan, arg.Cidr, arg.Reason, arg.ExpiresAt)
+	var i IpBan
+	err := row.Scan(
+		&i.ID,
+		&i.Cidr,
+		&i.Reason,
+		&i.ExpiresAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
 const createPlayer = `-- name: CreatePlayer :one
 insert into players (
     user_id, name, color
@@ -58,6 +87,85 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 	return i, err
 }
 
+const createUserBan = `-- name: CreateUserBan :one
+insert into user_bans (
+    user_id, reason, expires_at
+) values (
+    ?, ?, ?
+)
+returning id, user_id, reason, expires_at, created_at
+`
+
+type CreateUserBanParams struct {
+	UserID    int64
+	Reason    string
+	ExpiresAt sql.NullInt64
+}
+
+func (q *Queries) CreateUserBan(ctx context.Context, arg CreateUserBanParams) (UserBan, error) {
+	row := q.db.QueryRowContext(ctx, createUserBan, arg.UserID, arg.Reason, arg.ExpiresAt)
+	var i UserBan
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Reason,
+		&i.ExpiresAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const deleteIpBan = `-- name: DeleteIpBan :execrows
+delete from ip_bans
+where id = ?
+`
+
+func (q *Queries) DeleteIpBan(ctx context.Context, id int64) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteIpBan, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteUserBan = `-- name: DeleteUserBan :execrows
+delete from user_bans
+where id = ?
+`
+
+func (q *Queries) DeleteUserBan(ctx context.Context, id int64) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteUserBan, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const getActiveUserBan = `-- name: GetActiveUserBan :one
+select id, user_id, reason, expires_at, created_at from user_bans
+where user_id = ? and (expires_at is null or expires_at > ?)
+order by expires_at is null desc, expires_at desc
+limit 1
+`
+
+type GetActiveUserBanParams struct {
+	UserID int64
+	Now    sql.NullInt64
+}
+
+func (q *Queries) GetActiveUserBan(ctx context.Context, arg GetActiveUserBanParams) (UserBan, error) {
+	row := q.db.QueryRowContext(ctx, getActiveUserBan, arg.UserID, arg.Now)
+	var i UserBan
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Reason,
+		&i.ExpiresAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
 const getPlayerByName = `-- name: GetPlayerByName :one
 select id, user_id, name, best_score, color from players
 where name LIKE ? 
@@ -110,6 +218,24 @@ func (q *Queries) GetPlayerRank(ctx context.Context, id int64) (int64, error) {
 	return rank, err
 }
 
+const getSuspicionScore = `-- name: GetSuspicionScore :one
+select player_id, score, offences, last_offence, updated_at from suspicion_scores
+where player_id = ? limit 1
+`
+
+func (q *Queries) GetSuspicionScore(ctx context.Context, playerID int64) (SuspicionScore, error) {
+	row := q.db.QueryRowContext(ctx, getSuspicionScore, playerID)
+	var i SuspicionScore
+	err := row.Scan(
+		&i.PlayerID,
+		&i.Score,
+		&i.Offences,
+		&i.LastOffence,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
 const getTopScores = `-- name: GetTopScores :many
 select name, best_score
 from players
@@ -163,6 +289,171 @@ func (q *Queries) GetUserByUsername(ctx context.Context, username string) (User,
 	return i, err
 }
 
+const listActiveIpBans = `-- name: ListActiveIpBans :many
+select id, cidr, reason, expires_at, created_at from ip_bans
+where expires_at is null or expires_at > ?
+order by id
+`
+
+func (q *Queries) ListActiveIpBans(ctx context.Context, now sql.NullInt64) ([]IpBan, error) {
+	rows, err := q.db.QueryContext(ctx, listActiveIpBans, now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []IpBan
+	for rows.Next() {
+		var i IpBan
+		if err := rows.Scan(
+			&i.ID,
+			&i.Cidr,
+			&i.Reason,
+			&i.ExpiresAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listActiveUserBans = `-- name: ListActiveUserBans :many
+select user_bans.id, user_bans.user_id, users.username, user_bans.reason, user_bans.expires_at, user_bans.created_at
+from user_bans
+join users on users.id = user_bans.user_id
+where user_bans.expires_at is null or user_bans.expires_at > ?
+order by user_bans.id
+`
+
+type ListActiveUserBansRow struct {
+	ID        int64
+	UserID    int64
+	Username  string
+	Reason    string
+	ExpiresAt sql.NullInt64
+	CreatedAt int64
+}
+
+func (q *Queries) ListActiveUserBans(ctx context.Context, now sql.NullInt64) ([]ListActiveUserBansRow, error) {
+	rows, err := q.db.QueryContext(ctx, listActiveUserBans, now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListActiveUserBansRow
+	for rows.Next() {
+		var i ListActiveUserBansRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Username,
+			&i.Reason,
+			&i.ExpiresAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSuspicionScores = `-- name: ListSuspicionScores :many
+select players.name, suspicion_scores.player_id, suspicion_scores.score, suspicion_scores.offences, suspicion_scores.last_offence, suspicion_scores.updated_at
+from suspicion_scores
+join players on players.id = suspicion_scores.player_id
+order by suspicion_scores.score * pow(0.5, (cast(? as integer) - suspicion_scores.updated_at) * cast(? as real)) desc
+limit ?
+`
+
+type ListSuspicionScoresParams struct {
+	Now               int64
+	HalvingsPerSecond float64
+	Limit             int64
+}
+
+type ListSuspicionScoresRow struct {
+	Name        string
+	PlayerID    int64
+	Score       float64
+	Offences    int64
+	LastOffence string
+	UpdatedAt   int64
+}
+
+func (q *Queries) ListSuspicionScores(ctx context.Context, arg ListSuspicionScoresParams) ([]ListSuspicionScoresRow, error) {
+	rows, err := q.db.QueryContext(ctx, listSuspicionScores, arg.Now, arg.HalvingsPerSecond, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListSuspicionScoresRow
+	for rows.Next() {
+		var i ListSuspicionScoresRow
+		if err := rows.Scan(
+			&i.Name,
+			&i.PlayerID,
+			&i.Score,
+			&i.Offences,
+			&i.LastOffence,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const recordOffence = `-- name: RecordOffence :exec
+insert into suspicion_scores (
+    player_id, score, last_offence, updated_at
+) values (
+    ?, ?, ?, ?
+)
+on conflict (player_id) do update
+set score = excluded.score,
+    offences = offences + 1,
+    last_offence = excluded.last_offence,
+    updated_at = excluded.updated_at
+`
+
+type RecordOffenceParams struct {
+	PlayerID    int64
+	Score       float64
+	LastOffence string
+	UpdatedAt   int64
+}
+
+func (q *Queries) RecordOffence(ctx context.Context, arg RecordOffenceParams) error {
+	_, err := q.db.ExecContext(ctx, recordOffence,
+		arg.PlayerID,
+		arg.Score,
+		arg.LastOffence,
+		arg.UpdatedAt,
+	)
+	return err
+}
+
 const updatePlayerBestScore = `-- name: UpdatePlayerBestScore :exec
 update players
 set best_score = ?
@@ -0,0 +1,44 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"server/internal/server/metrics"
+	"strings"
+	"time"
+)
+
+// Times every query that goes through it, labelled with the query's name from queries.sql
+type instrumentedDb struct {
+	*sql.DB
+}
+
+func (d instrumentedDb) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	defer metrics.DbQuerySeconds.ObserveSince(queryName(query), time.Now())
+	return d.DB.ExecContext(ctx, query, args...)
+}
+
+func (d instrumentedDb) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	defer metrics.DbQuerySeconds.ObserveSince(queryName(query), time.Now())
+	return d.DB.PrepareContext(ctx, query)
+}
+
+func (d instrumentedDb) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	defer metrics.DbQuerySeconds.ObserveSince(queryName(query), time.Now())
+	return d.DB.QueryContext(ctx, query, args...)
+}
+
+func (d instrumentedDb) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	defer metrics.DbQuerySeconds.ObserveSince(queryName(query), time.Now())
+	return d.DB.QueryRowContext(ctx, query, args...)
+}
+
+// Generated queries start with a "-- name: GetUserByUsername :one" comment
+func queryName(query string) string {
+	name, found := strings.CutPrefix(query, "-- name: ")
+	if !found {
+		return "other"
+	}
+	name, _, _ = strings.Cut(name, " ")
+	return name
+}
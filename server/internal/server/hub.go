@@ -4,19 +4,34 @@ import (
 	"context"
 	"database/sql"
 	_ "embed"
+	"errors"
+	"fmt"
 	"log"
+	"log/slog"
 	"math/rand/v2"
+	"net"
 	"net/http"
 	"path"
 	"server/internal/server/db"
+	"server/internal/server/metrics"
 	"server/internal/server/objects"
+	"server/internal/server/replay"
+	"server/internal/server/sim"
 	"server/pkg/packets"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	_ "modernc.org/sqlite"
 )
 
-const MaxSpores = 1000
+const (
+	// Defaults for the game settings, see GameSettings
+	MaxSpores   = 1000
+	MaxPowerUps = 20
+
+	broadcastBufferSize = 1024
+)
 
 //go:embed db/config/schema.sql
 var schemaGenSql string
@@ -29,13 +44,14 @@ type DbTx struct {
 func (h *Hub) NewDbTx() *DbTx {
 	return &DbTx{
 		Ctx:     context.Background(),
-		Queries: db.New(h.dbPool),
+		Queries: db.New(instrumentedDb{h.dbPool}),
 	}
 }
 
 type SharedGameObjects struct {
-	Players *objects.SharedCollection[*objects.Player]
-	Spores  *objects.SharedCollection[*objects.Spore]
+	Players  *objects.SharedCollection[*objects.Player]
+	Spores   *objects.SharedCollection[*objects.Spore]
+	PowerUps *objects.SharedCollection[*objects.PowerUp]
 }
 
 type ClientStateHandler interface {
@@ -51,15 +67,83 @@ type ClientInterfacer interface {
 	SetState(newState ClientStateHandler)
 	Id() uint64
 	ProcessMessage(senderId uint64, message packets.Msg)
+	// Runs the event on the client's own event loop, where all of its state handling happens
+	Post(event func())
 	SocketSend(message packets.Msg)
 	SocketSendAs(message packets.Msg, senderId uint64)
+	// Like SocketSendAs, for messages the client can't do without, like the players it's shown
+	// on entering the game. Transports that send some messages unreliably never send these so.
+	SocketSendReliably(message packets.Msg, senderId uint64)
 	PassToPeer(message packets.Msg, peerId uint64)
 	Broadcast(message packets.Msg)
 	ReadPump()
 	WritePump()
 	Close(reason string)
+	// The state the client is in. Only safe to call from the client's event loop.
+	State() ClientStateHandler
+	// What the client is up to, safe to call from anywhere
+	Status() ClientStatus
+	// Round trip time to the client as last measured by its transport, zero if unknown
+	RTT() time.Duration
+	// Logs with the client's id, how it's connected and, once it has logged in, its username
+	Logger() *slog.Logger
+	// Attaches the player the client is logged in as to its logs and suspicion score, or
+	// detaches it if nil
+	Identify(player *objects.Player)
+	// Counts the offence against the player the client is logged in as, kicking them if
+	// they've become too suspicious
+	Suspect(offence Offence, err error)
 	DbTx() *DbTx
 	SharedGameObjects() *SharedGameObjects
+	// The game settings as they stand, which can change while the server runs
+	Settings() GameSettings
+	// The clock and randomness the client's game runs on, with random numbers of its own
+	Sim() *sim.Sim
+	// Where recorded games are kept, nil if there's nowhere to keep them
+	Replays() *replay.Library
+}
+
+// A client's state and who it's logged in as, for showing to operators
+type ClientStatus struct {
+	State    string
+	Username string
+}
+
+// Game rules that can be changed while the server is running
+type GameSettings struct {
+	MaxSpores int
+	// How often spores that were eaten are put back, and how many at most each time
+	SporeReplenishInterval time.Duration
+	SporeReplenishBatch    int
+}
+
+var DefaultGameSettings = GameSettings{
+	MaxSpores:              MaxSpores,
+	SporeReplenishInterval: 5 * time.Second,
+	SporeReplenishBatch:    10,
+}
+
+func (s GameSettings) Validate() error {
+	if s.MaxSpores < 0 {
+		return errors.New("max spores can't be negative")
+	}
+	if s.SporeReplenishInterval <= 0 {
+		return errors.New("spore replenish interval must be positive")
+	}
+	if s.SporeReplenishBatch <= 0 {
+		return errors.New("spore replenish batch must be positive")
+	}
+	return nil
+}
+
+// Counts the messages clients couldn't keep up with, see clients.OverflowPolicy
+type DropCounters struct {
+	// Player updates replaced by a newer one before they could be sent
+	Coalesced atomic.Uint64
+	// Messages thrown away outright
+	Dropped atomic.Uint64
+	// Clients disconnected for falling too far behind
+	Disconnected atomic.Uint64
 }
 
 type Hub struct {
@@ -69,104 +153,489 @@ type Hub struct {
 	UnregisterChan chan ClientInterfacer
 
 	dbPool *sql.DB
+	// For the hub's own queries, like checking bans
+	queries *db.Queries
+
+	// Closed once the hub has finished shutting down, which stops all of its loops
+	stop         chan struct{}
+	shuttingDown atomic.Bool
 
 	SharedGameObjects *SharedGameObjects
+	// The clock and randomness the game runs on, set before calling Run. Seeded at random
+	// unless replaced.
+	Sim *sim.Sim
+
+	Drops DropCounters
+
+	// Set before calling Run
+	Limits      ConnectionLimits
+	AntiCheat   AntiCheatConfig
+	connections *connectionTracker
+
+	// Offences waiting to be saved
+	offences chan offenceRecord
+	// Loops that have to finish before the database is closed
+	loops sync.WaitGroup
+
+	settings atomic.Pointer[GameSettings]
+
+	// Where replays are recorded to and watched from, set before calling Run. Nil if there's
+	// nowhere to keep them.
+	Replays  *replay.Library
+	recorder *replay.Recorder
 }
 
 func NewHub(dataDirPath string) *Hub {
-	dbPool, err := sql.Open("sqlite", path.Join(dataDirPath, "db.sqlite"))
+	// Clients write to the database concurrently, so wait for each other's locks rather than failing
+	dbPool, err := sql.Open("sqlite", path.Join(dataDirPath, "db.sqlite")+"?_pragma=busy_timeout(5000)")
 	if err != nil {
 		log.Fatalf("Error opening database: %v", err)
 	}
-	return &Hub{
+	return NewHubWithDb(dbPool)
+}
+
+// Creates a hub on a database that's already open, like an in-memory one for tests. The hub
+// creates its tables on Run and closes the database on Shutdown.
+func NewHubWithDb(dbPool *sql.DB) *Hub {
+	hub := &Hub{
 		Clients:        objects.NewSharedCollection[ClientInterfacer](),
-		BroadcastChan:  make(chan *packets.Packet),
+		BroadcastChan:  make(chan *packets.Packet, broadcastBufferSize),
 		RegisterChan:   make(chan ClientInterfacer),
 		UnregisterChan: make(chan ClientInterfacer),
 		dbPool:         dbPool,
+		queries:        db.New(instrumentedDb{dbPool}),
+		stop:           make(chan struct{}),
+		AntiCheat:      DefaultAntiCheatConfig,
+		offences:       make(chan offenceRecord, offenceBufferSize),
+		connections:    newConnectionTracker(),
+		Sim:            sim.New(sim.SystemClock{}, rand.Uint64()),
 		SharedGameObjects: &SharedGameObjects{
-			Players: objects.NewSharedCollection[*objects.Player](),
-			Spores:  objects.NewSharedCollection[*objects.Spore](),
+			Players:  objects.NewSharedCollection[*objects.Player](),
+			Spores:   objects.NewSharedCollection[*objects.Spore](),
+			PowerUps: objects.NewSharedCollection[*objects.PowerUp](),
 		},
 	}
+	hub.settings.Store(&DefaultGameSettings)
+	return hub
+}
+
+func (h *Hub) Settings() GameSettings {
+	return *h.settings.Load()
+}
+
+// Takes effect the next time spores are replenished
+func (h *Hub) SetSettings(settings GameSettings) error {
+	if err := settings.Validate(); err != nil {
+		return err
+	}
+	h.settings.Store(&settings)
+	slog.Info("Changed game settings", "settings", fmt.Sprintf("%+v", settings))
+	return nil
 }
 
 func (h *Hub) Run() {
-	log.Println("Initializing database ..")
+	slog.Info("Initializing database")
 	if _, err := h.dbPool.ExecContext(context.Background(), schemaGenSql); err != nil {
 		log.Fatalf("Error initializing db: %v", err)
 	}
-	log.Println("Placing spores ..")
-	for i := 0; i < MaxSpores; i++ {
+	maxSpores := h.Settings().MaxSpores
+	slog.Info("Placing spores", "count", maxSpores)
+	for i := 0; i < maxSpores; i++ {
 		h.SharedGameObjects.Spores.Add(h.newSpore())
 	}
 
-	go h.replenishSporesLoop(5 * time.Second)
+	slog.Info("Placing power-ups", "count", MaxPowerUps)
+	for i := 0; i < MaxPowerUps; i++ {
+		h.SharedGameObjects.PowerUps.Add(h.newPowerUp())
+	}
 
-	log.Println("Awaiting client registration")
+	go h.replenishSporesLoop()
+	go h.replenishPowerUpsLoop(15 * time.Second)
+	go h.logDropsLoop(time.Minute)
+	h.loops.Add(1)
+	go h.recordOffencesLoop()
+	if h.recorder != nil {
+		go h.recorder.Run()
+	}
+
+	slog.Info("Awaiting client registration")
 	for {
 		select {
 		case client := <-h.RegisterChan:
+			start := time.Now()
 			client.Initialize(h.Clients.Add(client))
+			metrics.HubLoopSeconds.ObserveSince("register", start)
 		case client := <-h.UnregisterChan:
+			start := time.Now()
 			h.Clients.Remove(client.Id())
+			h.connections.remove(client)
+			metrics.HubLoopSeconds.ObserveSince("unregister", start)
+		case <-h.stop:
+			slog.Info("Hub stopped")
+			return
 		case packet := <-h.BroadcastChan:
+			start := time.Now()
+			if h.recorder != nil {
+				h.recorder.Record(packet)
+			}
+			// Only ever queues the message with each client, so a slow client can't hold up the hub
 			h.Clients.ForEach(func(clientId uint64, client ClientInterfacer) {
 				if clientId != packet.SenderId {
 					client.ProcessMessage(packet.SenderId, packet.Msg)
 				}
 			})
+			metrics.HubLoopSeconds.ObserveSince("broadcast", start)
 		}
 	}
 }
 
 func (h *Hub) Serve(getNewClient func(*Hub, http.ResponseWriter, *http.Request) (ClientInterfacer, error), w http.ResponseWriter, r *http.Request) {
-	log.Println("New client connected from ", r.RemoteAddr)
+	if h.shuttingDown.Load() {
+		http.Error(w, "Server is shutting down", http.StatusServiceUnavailable)
+		return
+	}
+
+	// The limits are checked before the bans, so a flood of connections is turned away
+	// without going to the database for each one
+	ip := remoteIP(r.RemoteAddr)
+	if status, reason, ok := h.connections.reserve(ip, h.Limits); !ok {
+		slog.Warn("Rejecting connection", "remote_addr", r.RemoteAddr, "reason", reason)
+		http.Error(w, reason, status)
+		return
+	}
+	if ban, banned := h.checkIPBan(r.Context(), ip); banned {
+		slog.Warn("Rejecting connection from banned address", "remote_addr", r.RemoteAddr, "ban_id", ban.ID, "reason", ban.Reason)
+		h.connections.cancel(ip)
+		http.Error(w, DescribeBan(ban.Reason, ban.ExpiresAt), http.StatusForbidden)
+		return
+	}
+
+	slog.Info("New client connected", "remote_addr", r.RemoteAddr)
 	client, err := getNewClient(h, w, r)
 
 	if err != nil {
-		log.Printf("Error obtaining client for new connection: %v\n\n", err)
+		slog.Error("Error obtaining client for new connection", "remote_addr", r.RemoteAddr, "error", err)
+		h.connections.cancel(ip)
+		return
+	}
+
+	h.connections.assign(client, ip)
+	h.Register(client)
+}
+
+// Like Serve, for transports that aren't HTTP, like raw TCP. Closes the connection if the
+// hub can't take the client.
+func (h *Hub) ServeConn(getNewClient func(*Hub, net.Conn) (ClientInterfacer, error), conn net.Conn) {
+	if h.shuttingDown.Load() {
+		conn.Close()
+		return
+	}
+
+	ip := remoteIP(conn.RemoteAddr().String())
+	if _, reason, ok := h.connections.reserve(ip, h.Limits); !ok {
+		slog.Warn("Rejecting connection", "remote_addr", conn.RemoteAddr().String(), "reason", reason)
+		conn.Close()
 		return
 	}
+	if ban, banned := h.checkIPBan(context.Background(), ip); banned {
+		slog.Warn("Rejecting connection from banned address", "remote_addr", conn.RemoteAddr().String(), "ban_id", ban.ID, "reason", ban.Reason)
+		h.connections.cancel(ip)
+		conn.Close()
+		return
+	}
+
+	slog.Info("New client connected", "remote_addr", conn.RemoteAddr().String())
+	client, err := getNewClient(h, conn)
+
+	if err != nil {
+		slog.Error("Error obtaining client for new connection", "remote_addr", conn.RemoteAddr().String(), "error", err)
+		h.connections.cancel(ip)
+		conn.Close()
+		return
+	}
+
+	h.connections.assign(client, ip)
+	h.Register(client)
+}
 
-	h.RegisterChan <- client
+// Serves every connection the listener accepts, until the listener is closed
+func (h *Hub) ServeListener(getNewClient func(*Hub, net.Conn) (ClientInterfacer, error), listener net.Listener) error {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return err
+		}
+		go h.ServeConn(getNewClient, conn)
+	}
+}
+
+// Implemented by clients with a connection of their own. The write pump normally closes it
+// on its way out, but a client the hub turns away never gets a write pump.
+type connCloser interface {
+	CloseConn() error
+}
+
+// Hands the client to the hub and starts its pumps, or closes it if the hub has stopped
+func (h *Hub) Register(client ClientInterfacer) {
+	select {
+	case h.RegisterChan <- client:
+	case <-h.stop:
+		client.Close("server is shutting down")
+		if conn, ok := client.(connCloser); ok {
+			conn.CloseConn()
+		}
+		return
+	}
 	go client.WritePump()
 	go client.ReadPump()
 }
 
-func (h *Hub) newSpore() *objects.Spore {
-	sporeRadius := max(10+rand.NormFloat64()*3, 5)
-	x, y := objects.SpawnCoords(sporeRadius, h.SharedGameObjects.Players, h.SharedGameObjects.Spores)
-	return &objects.Spore{
-		X:      x,
-		Y:      y,
-		Radius: sporeRadius,
+// Queues the packet for every client except its sender. Does nothing once the hub has stopped.
+func (h *Hub) Broadcast(packet *packets.Packet) {
+	select {
+	case h.BroadcastChan <- packet:
+	case <-h.stop:
 	}
 }
 
-func (h *Hub) replenishSporesLoop(rate time.Duration) {
-	ticker := time.NewTicker(rate)
+// Removes the client from the hub. Does nothing once the hub has stopped.
+func (h *Hub) Unregister(client ClientInterfacer) {
+	select {
+	case h.UnregisterChan <- client:
+	case <-h.stop:
+	}
+}
+
+// Tells the client why it's being disconnected and closes it. Returns false if there's no such client.
+func (h *Hub) Kick(clientId uint64, reason string) bool {
+	client, exists := h.Clients.Get(clientId)
+	if !exists {
+		return false
+	}
+
+	slog.Info("Kicking client", "client_id", clientId, "reason", reason)
+	client.SocketSend(packets.NewDisconnect(reason))
+	client.Close(reason)
+	return true
+}
+
+// Sends a chat message from the server, rather than any client, to everyone in game
+func (h *Hub) Announce(message string) {
+	slog.Info("Announcing", "message", message)
+	h.Broadcast(&packets.Packet{SenderId: 0, Msg: packets.NewChat(message)})
+}
+
+// The address the client connected from, if it came in through Serve or ServeConn
+func (h *Hub) RemoteIP(client ClientInterfacer) (string, bool) {
+	return h.connections.ip(client)
+}
+
+// Disconnects every client with the given reason, waits for them to leave (which for players
+// in game saves their best score), then stops the hub and closes the database. If the context
+// ends before every client has left, the hub is stopped regardless.
+func (h *Hub) Shutdown(ctx context.Context, reason string) error {
+	slog.Info("Shutting down", "reason", reason)
+	h.shuttingDown.Store(true)
+
+	h.Clients.ForEach(func(_ uint64, client ClientInterfacer) {
+		client.SocketSend(packets.NewDisconnect(reason))
+		client.Close(reason)
+	})
+
+	ticker := time.NewTicker(50 * time.Millisecond)
 	defer ticker.Stop()
 
-	for range ticker.C {
+	var err error
+	for h.Clients.Len() > 0 && err == nil {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			err = ctx.Err()
+			slog.Warn("Gave up waiting for clients to leave", "clients", h.Clients.Len(), "error", err)
+		}
+	}
+
+	close(h.stop)
+	h.loops.Wait()
+
+	if h.recorder != nil {
+		h.recorder.Close()
+	}
+
+	if dbErr := h.dbPool.Close(); dbErr != nil {
+		slog.Error("Error closing database", "error", dbErr)
+		err = errors.Join(err, dbErr)
+	}
+	return err
+}
+
+// Records everything broadcast into the replay library, starting a new replay every interval.
+// Call before Run.
+func (h *Hub) RecordReplays(interval time.Duration) error {
+	if h.Replays == nil {
+		return errors.New("no replay library to record into")
+	}
+	// Sim may yet be replaced, so it's only looked up when a packet is recorded
+	h.recorder = replay.NewRecorder(h.Replays, interval, h.worldSnapshot, func() time.Time { return h.Sim.Now() })
+	return nil
+}
+
+// The world as it stands, as a client joining now would be sent it. Everything that happens
+// to it after goes through BroadcastChan, so that's all a replay needs on top of this.
+func (h *Hub) worldSnapshot() []*packets.Packet {
+	var snapshot []*packets.Packet
+
+	h.SharedGameObjects.Players.ForEach(func(playerId uint64, player *objects.Player) {
+		snapshot = append(snapshot, &packets.Packet{SenderId: playerId, Msg: packets.NewPlayer(playerId, player, h.Sim.Now())})
+	})
+
+	const sporeBatchSize = 100
+	sporesBatch := make(map[uint64]*objects.Spore, sporeBatchSize)
+	h.SharedGameObjects.Spores.ForEach(func(sporeId uint64, spore *objects.Spore) {
+		sporesBatch[sporeId] = spore
+		if len(sporesBatch) >= sporeBatchSize {
+			snapshot = append(snapshot, &packets.Packet{Msg: packets.NewSporeBatch(sporesBatch)})
+			sporesBatch = make(map[uint64]*objects.Spore, sporeBatchSize)
+		}
+	})
+	if len(sporesBatch) > 0 {
+		snapshot = append(snapshot, &packets.Packet{Msg: packets.NewSporeBatch(sporesBatch)})
+	}
+
+	h.SharedGameObjects.PowerUps.ForEach(func(powerUpId uint64, powerUp *objects.PowerUp) {
+		snapshot = append(snapshot, &packets.Packet{Msg: packets.NewPowerUp(powerUpId, powerUp)})
+	})
+
+	return snapshot
+}
+
+func (h *Hub) newSpore() *objects.Spore {
+	return sim.NewSpore(h.Sim.Rand, h.SharedGameObjects.Players, h.SharedGameObjects.Spores)
+}
+
+// Follows the game settings as they change, picking up a new interval on the tick after
+func (h *Hub) replenishSporesLoop() {
+	interval := h.Settings().SporeReplenishInterval
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-h.stop:
+			return
+		}
+
+		settings := h.Settings()
+		if settings.SporeReplenishInterval != interval {
+			interval = settings.SporeReplenishInterval
+			ticker.Reset(interval)
+		}
+
 		sporesRemaining := h.SharedGameObjects.Spores.Len()
-		diff := MaxSpores - sporesRemaining
+		diff := settings.MaxSpores - sporesRemaining
 		if diff <= 0 {
 			continue
 		}
 
-		log.Printf("%d spores remain - going to replenish %d spores\n", sporesRemaining, diff)
+		slog.Debug("Replenishing spores", "remaining", sporesRemaining, "adding", diff)
 
-		for i := 0; i < min(diff, 10); i++ {
+		for i := 0; i < min(diff, settings.SporeReplenishBatch); i++ {
 			spore := h.newSpore()
 			sporeId := h.SharedGameObjects.Spores.Add(spore)
 
-			h.BroadcastChan <- &packets.Packet{
+			h.Broadcast(&packets.Packet{
 				SenderId: 0,
 				Msg:      packets.NewSpore(sporeId, spore),
-			}
+			})
 
 			time.Sleep(50 * time.Millisecond)
 		}
 	}
 }
+
+func (h *Hub) newPowerUp() *objects.PowerUp {
+	return sim.NewPowerUp(h.Sim.Rand, h.SharedGameObjects.Players, h.SharedGameObjects.Spores)
+}
+
+// Power-ups are meant to be rare, so only one is put back each tick
+func (h *Hub) replenishPowerUpsLoop(rate time.Duration) {
+	ticker := time.NewTicker(rate)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-h.stop:
+			return
+		}
+
+		if h.SharedGameObjects.PowerUps.Len() >= MaxPowerUps {
+			continue
+		}
+
+		powerUp := h.newPowerUp()
+		powerUpId := h.SharedGameObjects.PowerUps.Add(powerUp)
+
+		h.Broadcast(&packets.Packet{
+			SenderId: 0,
+			Msg:      packets.NewPowerUp(powerUpId, powerUp),
+		})
+	}
+}
+
+// Reports the hub's game objects and the messages its clients couldn't keep up with
+func (h *Hub) RegisterMetrics(registry *metrics.Registry) {
+	registry.NewGaugeFunc("game_players", "Players in game", func() float64 {
+		return float64(h.SharedGameObjects.Players.Len())
+	})
+	registry.NewGaugeFunc("game_spores", "Spores on the map", func() float64 {
+		return float64(h.SharedGameObjects.Spores.Len())
+	})
+	registry.NewGaugeFunc("game_power_ups", "Power-ups on the map", func() float64 {
+		return float64(h.SharedGameObjects.PowerUps.Len())
+	})
+	registry.NewGaugeFunc("game_broadcast_queue_length", "Packets waiting for the hub to broadcast them", func() float64 {
+		return float64(len(h.BroadcastChan))
+	})
+	registry.NewCounterFunc("game_player_updates_coalesced_total", "Player updates replaced by a newer one before they could be sent", func() float64 {
+		return float64(h.Drops.Coalesced.Load())
+	})
+	registry.NewCounterFunc("game_messages_dropped_total", "Messages to clients thrown away because the client couldn't keep up", func() float64 {
+		return float64(h.Drops.Dropped.Load())
+	})
+	registry.NewCounterFunc("game_slow_clients_disconnected_total", "Clients disconnected for falling too far behind", func() float64 {
+		return float64(h.Drops.Disconnected.Load())
+	})
+	registry.NewCounterFunc("game_replay_packets_dropped_total", "Broadcasts left out of the replay because the recorder fell behind", func() float64 {
+		if h.recorder == nil {
+			return 0
+		}
+		return float64(h.recorder.Dropped.Load())
+	})
+}
+
+func (h *Hub) logDropsLoop(rate time.Duration) {
+	ticker := time.NewTicker(rate)
+	defer ticker.Stop()
+
+	var lastCoalesced, lastDropped, lastDisconnected uint64
+	for {
+		select {
+		case <-ticker.C:
+		case <-h.stop:
+			return
+		}
+
+		coalesced, dropped, disconnected := h.Drops.Coalesced.Load(), h.Drops.Dropped.Load(), h.Drops.Disconnected.Load()
+		if coalesced == lastCoalesced && dropped == lastDropped && disconnected == lastDisconnected {
+			continue
+		}
+
+		slog.Warn("Clients falling behind", "player_updates_coalesced", coalesced, "messages_dropped", dropped, "clients_disconnected", disconnected)
+		lastCoalesced, lastDropped, lastDisconnected = coalesced, dropped, disconnected
+	}
+}
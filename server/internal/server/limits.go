@@ -0,0 +1,98 @@
+package server
+
+import (
+	"net"
+	"net/http"
+	"sync"
+)
+
+// Caps on the connections the hub accepts through Serve and ServeConn, zero meaning no limit
+type ConnectionLimits struct {
+	MaxClients      int
+	MaxClientsPerIP int
+}
+
+// Keeps count of the connections accepted through Serve and ServeConn, per IP and in total
+type connectionTracker struct {
+	mux       sync.Mutex
+	total     int
+	perIP     map[string]int
+	clientIPs map[ClientInterfacer]string
+}
+
+func newConnectionTracker() *connectionTracker {
+	return &connectionTracker{
+		perIP:     make(map[string]int),
+		clientIPs: make(map[ClientInterfacer]string),
+	}
+}
+
+// Reserves a slot for a new connection from the IP, or returns the HTTP status and reason to
+// reject it with if there's no room
+func (t *connectionTracker) reserve(ip string, limits ConnectionLimits) (int, string, bool) {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+
+	if limits.MaxClients > 0 && t.total >= limits.MaxClients {
+		return http.StatusServiceUnavailable, "Server is full", false
+	}
+	if limits.MaxClientsPerIP > 0 && t.perIP[ip] >= limits.MaxClientsPerIP {
+		return http.StatusTooManyRequests, "Too many connections from your address", false
+	}
+
+	t.total++
+	t.perIP[ip]++
+	return http.StatusOK, "", true
+}
+
+// Frees up a slot reserved for a connection that didn't end up with a client
+func (t *connectionTracker) cancel(ip string) {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+
+	t.release(ip)
+}
+
+func (t *connectionTracker) assign(client ClientInterfacer, ip string) {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+
+	t.clientIPs[client] = ip
+}
+
+func (t *connectionTracker) ip(client ClientInterfacer) (string, bool) {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+
+	ip, tracked := t.clientIPs[client]
+	return ip, tracked
+}
+
+// Frees up the client's slot, if it came in through Serve or ServeConn
+func (t *connectionTracker) remove(client ClientInterfacer) {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+
+	ip, tracked := t.clientIPs[client]
+	if !tracked {
+		return
+	}
+	delete(t.clientIPs, client)
+	t.release(ip)
+}
+
+func (t *connectionTracker) release(ip string) {
+	t.total--
+	t.perIP[ip]--
+	if t.perIP[ip] <= 0 {
+		delete(t.perIP, ip)
+	}
+}
+
+func remoteIP(remoteAddr string) string {
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		return remoteAddr
+	}
+	return host
+}
@@ -0,0 +1,14 @@
+package metrics
+
+var (
+	ClientsByState = Default.NewGaugeVec("game_clients", "Clients by the state they're in", "state")
+
+	PacketsIn       = Default.NewCounterVec("game_packets_in_total", "Packets received from clients, by message type", "type")
+	PacketsRejected = Default.NewCounterVec("game_packets_rejected_total", "Packets from clients thrown away as invalid or over budget, by message type", "type")
+	PacketsOut      = Default.NewCounterVec("game_packets_out_total", "Packets sent to clients, by message type", "type")
+	Offences        = Default.NewCounterVec("game_offences_total", "Suspicious things clients did, by offence", "offence")
+
+	HubLoopSeconds = Default.NewHistogramVec("game_hub_loop_seconds", "Time the hub takes to handle each event, by event", "event", DefaultBuckets)
+	DbQuerySeconds = Default.NewHistogramVec("game_db_query_seconds", "Time taken by database queries, by query", "query", DefaultBuckets)
+	BcryptSeconds  = Default.NewHistogramVec("game_bcrypt_seconds", "Time spent hashing and checking passwords, by operation", "operation", DefaultBuckets)
+)
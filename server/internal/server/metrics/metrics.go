@@ -0,0 +1,226 @@
+// Package metrics keeps counters, gauges and histograms in memory and serves them in the
+// Prometheus text format.
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Buckets in seconds, from a tenth of a millisecond up to ten seconds
+var DefaultBuckets = []float64{0.0001, 0.00025, 0.0005, 0.001, 0.0025, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+type collector interface {
+	write(w io.Writer)
+}
+
+type Registry struct {
+	mux        sync.Mutex
+	collectors []collector
+}
+
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// Where everything in this package is registered, unless said otherwise
+var Default = NewRegistry()
+
+func (r *Registry) register(c collector) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	r.collectors = append(r.collectors, c)
+}
+
+func (r *Registry) Write(w io.Writer) {
+	r.mux.Lock()
+	collectors := slices.Clone(r.collectors)
+	r.mux.Unlock()
+
+	for _, c := range collectors {
+		c.write(w)
+	}
+}
+
+func (r *Registry) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	r.Write(w)
+}
+
+func writeHeader(w io.Writer, name, help, kind string) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+}
+
+func formatLabel(label, value string) string {
+	if label == "" {
+		return ""
+	}
+	return fmt.Sprintf("{%s=%s}", label, strconv.Quote(value))
+}
+
+func formatFloat(value float64) string {
+	if math.IsInf(value, 1) {
+		return "+Inf"
+	}
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+// A number that only goes up, split by the value of one label
+type CounterVec struct {
+	name, help, label string
+	values            sync.Map // label value -> *atomic.Uint64
+}
+
+func (r *Registry) NewCounterVec(name, help, label string) *CounterVec {
+	c := &CounterVec{name: name, help: help, label: label}
+	r.register(c)
+	return c
+}
+
+func (c *CounterVec) Add(labelValue string, delta uint64) {
+	value, _ := c.values.LoadOrStore(labelValue, &atomic.Uint64{})
+	value.(*atomic.Uint64).Add(delta)
+}
+
+func (c *CounterVec) Inc(labelValue string) {
+	c.Add(labelValue, 1)
+}
+
+func (c *CounterVec) write(w io.Writer) {
+	writeHeader(w, c.name, c.help, "counter")
+	for _, labelValue := range sortedKeys(&c.values) {
+		value, _ := c.values.Load(labelValue)
+		fmt.Fprintf(w, "%s%s %d\n", c.name, formatLabel(c.label, labelValue), value.(*atomic.Uint64).Load())
+	}
+}
+
+// A number that goes up and down, split by the value of one label
+type GaugeVec struct {
+	name, help, label string
+	values            sync.Map // label value -> *atomic.Int64
+}
+
+func (r *Registry) NewGaugeVec(name, help, label string) *GaugeVec {
+	g := &GaugeVec{name: name, help: help, label: label}
+	r.register(g)
+	return g
+}
+
+func (g *GaugeVec) Add(labelValue string, delta int64) {
+	value, _ := g.values.LoadOrStore(labelValue, &atomic.Int64{})
+	value.(*atomic.Int64).Add(delta)
+}
+
+func (g *GaugeVec) write(w io.Writer) {
+	writeHeader(w, g.name, g.help, "gauge")
+	for _, labelValue := range sortedKeys(&g.values) {
+		value, _ := g.values.Load(labelValue)
+		fmt.Fprintf(w, "%s%s %d\n", g.name, formatLabel(g.label, labelValue), value.(*atomic.Int64).Load())
+	}
+}
+
+// A number read whenever the metrics are, for things that are already counted elsewhere
+type funcMetric struct {
+	name, help, kind string
+	value            func() float64
+}
+
+func (r *Registry) NewGaugeFunc(name, help string, value func() float64) {
+	r.register(&funcMetric{name: name, help: help, kind: "gauge", value: value})
+}
+
+func (r *Registry) NewCounterFunc(name, help string, value func() float64) {
+	r.register(&funcMetric{name: name, help: help, kind: "counter", value: value})
+}
+
+func (m *funcMetric) write(w io.Writer) {
+	writeHeader(w, m.name, m.help, m.kind)
+	fmt.Fprintf(w, "%s %s\n", m.name, formatFloat(m.value()))
+}
+
+// Counts observations into buckets, split by the value of one label
+type HistogramVec struct {
+	name, help, label string
+	buckets           []float64
+	histograms        sync.Map // label value -> *histogram
+}
+
+type histogram struct {
+	counts []atomic.Uint64 // One per bucket, plus one for +Inf
+	count  atomic.Uint64
+	sum    atomic.Uint64 // Bits of a float64
+}
+
+// Pass an empty label for a histogram that isn't split by anything
+func (r *Registry) NewHistogramVec(name, help, label string, buckets []float64) *HistogramVec {
+	h := &HistogramVec{name: name, help: help, label: label, buckets: buckets}
+	r.register(h)
+	return h
+}
+
+func (h *HistogramVec) Observe(labelValue string, value float64) {
+	stored, exists := h.histograms.Load(labelValue)
+	if !exists {
+		stored, _ = h.histograms.LoadOrStore(labelValue, &histogram{counts: make([]atomic.Uint64, len(h.buckets)+1)})
+	}
+	hist := stored.(*histogram)
+
+	bucket, _ := slices.BinarySearch(h.buckets, value)
+	hist.counts[bucket].Add(1)
+	hist.count.Add(1)
+	for {
+		old := hist.sum.Load()
+		if hist.sum.CompareAndSwap(old, math.Float64bits(math.Float64frombits(old)+value)) {
+			break
+		}
+	}
+}
+
+// Observes how long it's been since the start, in seconds
+func (h *HistogramVec) ObserveSince(labelValue string, start time.Time) {
+	h.Observe(labelValue, time.Since(start).Seconds())
+}
+
+func (h *HistogramVec) write(w io.Writer) {
+	writeHeader(w, h.name, h.help, "histogram")
+	for _, labelValue := range sortedKeys(&h.histograms) {
+		stored, _ := h.histograms.Load(labelValue)
+		hist := stored.(*histogram)
+
+		labels := ""
+		if h.label != "" {
+			labels = fmt.Sprintf("%s=%s,", h.label, strconv.Quote(labelValue))
+		}
+
+		var cumulative uint64
+		for i, upperBound := range append(slices.Clone(h.buckets), math.Inf(1)) {
+			cumulative += hist.counts[i].Load()
+			fmt.Fprintf(w, "%s_bucket{%sle=%q} %d\n", h.name, labels, formatFloat(upperBound), cumulative)
+		}
+		labels = strings.TrimSuffix(labels, ",")
+		if labels != "" {
+			labels = "{" + labels + "}"
+		}
+		fmt.Fprintf(w, "%s_sum%s %s\n", h.name, labels, formatFloat(math.Float64frombits(hist.sum.Load())))
+		fmt.Fprintf(w, "%s_count%s %d\n", h.name, labels, hist.count.Load())
+	}
+}
+
+func sortedKeys(m *sync.Map) []string {
+	var keys []string
+	m.Range(func(key, _ any) bool {
+		keys = append(keys, key.(string))
+		return true
+	})
+	slices.Sort(keys)
+	return keys
+}
@@ -12,6 +12,28 @@ type Player struct {
 	BestScore int64
 	DbId      int64
 	Color     int32
+	IsBot     bool
+	// Until this time the player can't be consumed, to give them a chance to get away from where they spawned
+	SpawnProtectedUntil time.Time
+	// Active power-up effects and when they run out
+	Effects map[PowerUpKind]time.Time
+	// The last numbered input from the client applied to the player, and the client's time
+	// when it sent it, zero if the client doesn't number its inputs
+	InputSequence   uint32
+	InputClientTime float64
+	// Where the player has been lately, shared by every snapshot of it
+	History *PositionHistory
+}
+
+// Players are only ever changed by the client that owns them, everyone else gets to see a
+// copy through the shared collection that's swapped out whenever the owner publishes changes
+func (p *Player) Snapshot() *Player {
+	snapshot := *p
+	return &snapshot
+}
+
+func (p *Player) IsSpawnProtected(now time.Time) bool {
+	return now.Before(p.SpawnProtectedUntil)
 }
 
 type Spore struct {
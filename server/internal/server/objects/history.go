@@ -0,0 +1,93 @@
+package objects
+
+import (
+	"sync"
+	"time"
+)
+
+// Where a player was, and how big, at a point in time
+type Position struct {
+	At     time.Time
+	X      float64
+	Y      float64
+	Radius float64
+}
+
+// The last few positions of a player, oldest being overwritten first, for judging claims by
+// what the client saw rather than where the player is by the time the claim arrives. Written
+// by the client that owns the player and read by anyone holding a snapshot of it, so it's
+// safe to share between goroutines. A nil history has nothing in it.
+type PositionHistory struct {
+	mux       sync.Mutex
+	positions []Position
+	// Where the next position goes, which is also the oldest once the history is full
+	next int
+	full bool
+}
+
+func NewPositionHistory(size int) *PositionHistory {
+	return &PositionHistory{positions: make([]Position, size)}
+}
+
+func (h *PositionHistory) Record(position Position) {
+	if h == nil {
+		return
+	}
+	h.mux.Lock()
+	defer h.mux.Unlock()
+
+	h.positions[h.next] = position
+	h.next = (h.next + 1) % len(h.positions)
+	if h.next == 0 {
+		h.full = true
+	}
+}
+
+// Oldest first
+func (h *PositionHistory) all() []Position {
+	if !h.full {
+		return append([]Position(nil), h.positions[:h.next]...)
+	}
+	return append(append([]Position(nil), h.positions[h.next:]...), h.positions[:h.next]...)
+}
+
+// The positions recorded since the given time, oldest first, starting with the last one
+// before it so the path the player took from then on is complete
+func (h *PositionHistory) Since(t time.Time) []Position {
+	if h == nil {
+		return nil
+	}
+	h.mux.Lock()
+	defer h.mux.Unlock()
+
+	positions := h.all()
+	for i := len(positions) - 1; i >= 0; i-- {
+		if !positions[i].At.After(t) {
+			return positions[i:]
+		}
+	}
+	return positions
+}
+
+// Where the player was at the given time, in between the positions recorded either side of
+// it. Times outside the history get the nearest position there is. False if there's none.
+func (h *PositionHistory) At(t time.Time) (Position, bool) {
+	positions := h.Since(t)
+	if len(positions) == 0 {
+		return Position{}, false
+	}
+
+	before := positions[0]
+	if len(positions) == 1 || !before.At.Before(t) {
+		return before, true
+	}
+	after := positions[1]
+
+	f := float64(t.Sub(before.At)) / float64(after.At.Sub(before.At))
+	return Position{
+		At:     t,
+		X:      before.X + (after.X-before.X)*f,
+		Y:      before.Y + (after.Y-before.Y)*f,
+		Radius: before.Radius + (after.Radius-before.Radius)*f,
+	}, true
+}
@@ -0,0 +1,75 @@
+package objects
+
+import "time"
+
+// Values match the PowerUpKind enum in packets.proto
+type PowerUpKind int32
+
+const (
+	PowerUpSpeedBoost PowerUpKind = iota + 1
+	PowerUpShield
+	PowerUpMagnet
+)
+
+var PowerUpKinds = []PowerUpKind{PowerUpSpeedBoost, PowerUpShield, PowerUpMagnet}
+
+// How long an effect lasts when a single power-up of this kind is picked up
+func (k PowerUpKind) Duration() time.Duration {
+	switch k {
+	case PowerUpSpeedBoost:
+		return 8 * time.Second
+	case PowerUpShield:
+		return 5 * time.Second
+	case PowerUpMagnet:
+		return 10 * time.Second
+	}
+	return 0
+}
+
+// Picking up the same kind of power-up again extends the effect, up to this many times its duration
+const maxEffectStack = 3
+
+type PowerUp struct {
+	X      float64
+	Y      float64
+	Radius float64
+	Kind   PowerUpKind
+}
+
+func (p *Player) HasEffect(kind PowerUpKind, now time.Time) bool {
+	return now.Before(p.Effects[kind])
+}
+
+func (p *Player) EffectRemaining(kind PowerUpKind, now time.Time) time.Duration {
+	return max(0, p.Effects[kind].Sub(now))
+}
+
+// Effects are replaced rather than modified in place, since other clients may be reading the
+// player's current effects while they change
+func (p *Player) AddEffect(kind PowerUpKind, now time.Time) {
+	remaining := max(0, p.Effects[kind].Sub(now))
+	expiry := now.Add(min(remaining+kind.Duration(), maxEffectStack*kind.Duration()))
+
+	effects := make(map[PowerUpKind]time.Time, len(p.Effects)+1)
+	for k, v := range p.Effects {
+		effects[k] = v
+	}
+	effects[kind] = expiry
+	p.Effects = effects
+}
+
+// Removes effects that have run out, returning whether any did
+func (p *Player) ExpireEffects(now time.Time) bool {
+	effects := make(map[PowerUpKind]time.Time, len(p.Effects))
+	for k, v := range p.Effects {
+		if now.Before(v) {
+			effects[k] = v
+		}
+	}
+
+	if len(effects) == len(p.Effects) {
+		return false
+	}
+	p.Effects = effects
+	return true
+}
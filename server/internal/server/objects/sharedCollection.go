@@ -38,6 +38,18 @@ func (s *SharedCollection[T]) Add(obj T, id ...uint64) uint64 {
 	return thisId
 }
 
+// Swaps in a new object for the given id, but only if the id is still in the collection
+func (s *SharedCollection[T]) Replace(id uint64, obj T) bool {
+	s.mapMux.Lock()
+	defer s.mapMux.Unlock()
+
+	if _, found := s.objectMap[id]; !found {
+		return false
+	}
+	s.objectMap[id] = obj
+	return true
+}
+
 func (s *SharedCollection[T]) Remove(id uint64) {
 	s.mapMux.Lock()
 	defer s.mapMux.Unlock()
@@ -67,5 +79,8 @@ func (s *SharedCollection[T]) Get(id uint64) (T, bool) {
 }
 
 func (s *SharedCollection[T]) Len() int {
+	s.mapMux.Lock()
+	defer s.mapMux.Unlock()
+
 	return len(s.objectMap)
 }
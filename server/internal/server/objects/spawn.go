@@ -1,6 +1,9 @@
 package objects
 
-import "math/rand/v2"
+import (
+	"math"
+	"math/rand/v2"
+)
 
 var getPlayerPosition = func(p *Player) (float64, float64) { return p.X, p.Y }
 var getPlayerRadius = func(p *Player) float64 { return p.Radius }
@@ -36,14 +39,14 @@ func isTooClose[T any](x float64, y float64, radius float64, objects *SharedColl
 	return tooClose
 }
 
-func SpawnCoords(radius float64, playersToAvoid *SharedCollection[*Player], sporesToAvoid *SharedCollection[*Spore]) (float64, float64) {
+func SpawnCoords(rng *rand.Rand, radius float64, playersToAvoid *SharedCollection[*Player], sporesToAvoid *SharedCollection[*Spore]) (float64, float64) {
 	bound := 3000.0
 	const maxTries int = 25
 
 	tries := 0
 	for {
-		x := bound * (2*rand.Float64() - 1)
-		y := bound * (2*rand.Float64() - 1)
+		x := bound * (2*rng.Float64() - 1)
+		y := bound * (2*rng.Float64() - 1)
 
 		if !isTooClose(x, y, radius, playersToAvoid, getPlayerPosition, getPlayerRadius) &&
 			!isTooClose(x, y, radius, sporesToAvoid, getSporePosition, getSporeRadius) {
@@ -56,6 +59,41 @@ func SpawnCoords(radius float64, playersToAvoid *SharedCollection[*Player], spor
 			tries = 0
 		}
 	}
+}
+
+// Picks the best of several non-overlapping spawn points, where the best point is the one
+// furthest away from the players around it. Distance to bigger players counts for less the
+// more massive they are, so a giant far away is still considered more dangerous than a
+// player of similar size close by.
+func SafeSpawnCoords(rng *rand.Rand, radius float64, playersToAvoid *SharedCollection[*Player], sporesToAvoid *SharedCollection[*Spore]) (float64, float64) {
+	const candidates int = 10
+
+	bestX, bestY := SpawnCoords(rng, radius, playersToAvoid, sporesToAvoid)
+	bestScore := threatScore(bestX, bestY, radius, playersToAvoid)
+
+	for i := 1; i < candidates; i++ {
+		x, y := SpawnCoords(rng, radius, playersToAvoid, sporesToAvoid)
+		if score := threatScore(x, y, radius, playersToAvoid); score > bestScore {
+			bestX, bestY, bestScore = x, y, score
+		}
+	}
+
+	return bestX, bestY
+}
+
+// Returns the mass-weighted distance to the most threatening player, higher is safer
+func threatScore(x float64, y float64, radius float64, players *SharedCollection[*Player]) float64 {
+	score := math.Inf(1)
+	if players == nil {
+		return score
+	}
+
+	ourMass := radius * radius
+	players.ForEach(func(_ uint64, player *Player) {
+		gap := math.Hypot(player.X-x, player.Y-y) - player.Radius - radius
+		massRatio := player.Radius * player.Radius / ourMass
+		score = min(score, gap/massRatio)
+	})
 
-	return rand.Float64() * bound, rand.Float64() * bound
+	return score
 }
@@ -0,0 +1,128 @@
+package replay
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	fileExtension = ".replay.gz"
+	// Replays are named after when they started, which also keeps names safe to put in a path.
+	// Any that start in the same second as another, like across a restart, are numbered from 2
+	// after a dot.
+	nameLayout = "20060102-150405"
+)
+
+var ErrNoReplays = errors.New("there are no replays")
+
+// The replay files in a directory
+type Library struct {
+	dir string
+}
+
+// Creates the directory if it doesn't exist yet
+func NewLibrary(dir string) (*Library, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &Library{dir: dir}, nil
+}
+
+func (l *Library) path(name string) string {
+	return filepath.Join(l.dir, name+fileExtension)
+}
+
+func nameFor(start time.Time) string {
+	return start.UTC().Format(nameLayout)
+}
+
+// When the replay with the given name started, and its number among those that started then
+func parseName(name string) (time.Time, int, error) {
+	startName, seqName, numbered := strings.Cut(name, ".")
+	start, err := time.Parse(nameLayout, startName)
+	if err != nil {
+		return time.Time{}, 0, fmt.Errorf("%q is not a replay name", name)
+	}
+	if !numbered {
+		return start, 1, nil
+	}
+
+	seq, err := strconv.Atoi(seqName)
+	if err != nil || seq < 2 || strconv.Itoa(seq) != seqName {
+		return time.Time{}, 0, fmt.Errorf("%q is not a replay name", name)
+	}
+	return start, seq, nil
+}
+
+// Creates a new replay named after when it starts, returning the name it ended up with
+func (l *Library) create(start time.Time) (*Writer, string, error) {
+	base := nameFor(start)
+	name := base
+	for seq := 2; ; seq++ {
+		writer, err := Create(l.path(name), start)
+		if !errors.Is(err, fs.ErrExist) {
+			return writer, name, err
+		}
+		name = fmt.Sprintf("%s.%d", base, seq)
+	}
+}
+
+// Returns the names of the replays, oldest first
+func (l *Library) List() ([]string, error) {
+	entries, err := os.ReadDir(l.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	type entryName struct {
+		name  string
+		start time.Time
+		seq   int
+	}
+	var found []entryName
+	for _, entry := range entries {
+		name, isReplay := strings.CutSuffix(entry.Name(), fileExtension)
+		if !isReplay || !entry.Type().IsRegular() {
+			continue
+		}
+		if start, seq, err := parseName(name); err == nil {
+			found = append(found, entryName{name, start, seq})
+		}
+	}
+	slices.SortFunc(found, func(a, b entryName) int {
+		return cmp.Or(a.start.Compare(b.start), cmp.Compare(a.seq, b.seq))
+	})
+
+	var names []string
+	for _, entry := range found {
+		names = append(names, entry.name)
+	}
+	return names, nil
+}
+
+// Opens the replay with the given name, or the latest one if the name is empty
+func (l *Library) Open(name string) (*Reader, error) {
+	if name == "" {
+		names, err := l.List()
+		if err != nil {
+			return nil, err
+		}
+		if len(names) == 0 {
+			return nil, ErrNoReplays
+		}
+		name = names[len(names)-1]
+	}
+
+	if _, _, err := parseName(name); err != nil {
+		return nil, err
+	}
+	return Open(l.path(name))
+}
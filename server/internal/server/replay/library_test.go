@@ -0,0 +1,74 @@
+package replay
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestReplaysStartingInTheSameSecond(t *testing.T) {
+	library, err := NewLibrary(t.TempDir())
+	if err != nil {
+		t.Fatalf("Error creating library: %v", err)
+	}
+
+	start := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	var created []string
+	for i := range 11 {
+		writer, name, err := library.create(start.Add(time.Duration(i) * time.Millisecond))
+		if err != nil {
+			t.Fatalf("Error creating replay %d: %v", i, err)
+		}
+		if err := writer.Close(); err != nil {
+			t.Fatalf("Error closing replay %s: %v", name, err)
+		}
+		created = append(created, name)
+	}
+
+	if created[0] != "20240501-120000" || created[1] != "20240501-120000.2" || created[10] != "20240501-120000.11" {
+		t.Errorf("Replays were named %v", created)
+	}
+
+	names, err := library.List()
+	if err != nil {
+		t.Fatalf("Error listing replays: %v", err)
+	}
+	if !slices.Equal(names, created) {
+		t.Errorf("Listed %v, want them in the order they were created, %v", names, created)
+	}
+
+	reader, err := library.Open("")
+	if err != nil {
+		t.Fatalf("Error opening the latest replay: %v", err)
+	}
+	reader.Close()
+
+	for _, name := range []string{"20240501-120000.1", "20240501-120000.02", "20240501-120000.x", "../20240501-120000"} {
+		if _, err := library.Open(name); err == nil {
+			t.Errorf("Opened %q, which isn't a replay name", name)
+		}
+	}
+}
+
+func TestCloseRecorderThatNeverRan(t *testing.T) {
+	library, err := NewLibrary(t.TempDir())
+	if err != nil {
+		t.Fatalf("Error creating library: %v", err)
+	}
+	recorder := NewRecorder(library, time.Minute, nil, time.Now)
+
+	closed := make(chan struct{})
+	go func() {
+		recorder.Close()
+		close(closed)
+	}()
+
+	select {
+	case <-closed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Close waited for a recorder that never ran")
+	}
+
+	// Running it afterwards stops straight away
+	recorder.Run()
+}
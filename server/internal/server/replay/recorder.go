@@ -0,0 +1,159 @@
+package replay
+
+import (
+	"log/slog"
+	"server/pkg/packets"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	recordBufferSize = 1024
+	// How often what's been recorded is pushed out to the file, so a crash loses little
+	flushInterval = time.Second
+)
+
+type timedPacket struct {
+	at     time.Time
+	packet *packets.Packet
+}
+
+// Writes packets to a series of replay files in the library, moving on to a new file every
+// interval so no one file gets too long to watch. Each file starts with a snapshot of the
+// world, so it can be watched without having seen what came before. Nothing is written while
+// nothing happens.
+type Recorder struct {
+	library  *Library
+	interval time.Duration
+	snapshot func() []*packets.Packet
+	// The game's clock, which packets are timed by
+	now     func() time.Time
+	packets chan timedPacket
+
+	stop      chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+
+	// Whether Run has started, and whether it's too late to, so Close knows whether to wait
+	stateMux sync.Mutex
+	running  bool
+	closed   bool
+
+	// Packets that came in faster than they could be written
+	Dropped atomic.Uint64
+}
+
+func NewRecorder(library *Library, interval time.Duration, snapshot func() []*packets.Packet, now func() time.Time) *Recorder {
+	return &Recorder{
+		library: library,
+		// Files are named to the second, so there's no point starting them any closer together
+		interval: max(interval, time.Second),
+		snapshot: snapshot,
+		now:      now,
+		packets:  make(chan timedPacket, recordBufferSize),
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+// Queues the packet to be written without waiting, dropping it if the recorder is behind
+func (r *Recorder) Record(packet *packets.Packet) {
+	select {
+	case r.packets <- timedPacket{at: r.now(), packet: packet}:
+	default:
+		r.Dropped.Add(1)
+	}
+}
+
+// Writes packets until the recorder is closed
+func (r *Recorder) Run() {
+	defer close(r.done)
+
+	r.stateMux.Lock()
+	if r.closed {
+		r.stateMux.Unlock()
+		return
+	}
+	r.running = true
+	r.stateMux.Unlock()
+
+	var writer *Writer
+	closeWriter := func() {
+		if writer == nil {
+			return
+		}
+		if err := writer.Close(); err != nil {
+			slog.Error("Error closing replay", "error", err)
+		}
+		writer = nil
+	}
+	defer closeWriter()
+
+	rotateTicker := time.NewTicker(r.interval)
+	defer rotateTicker.Stop()
+	flushTicker := time.NewTicker(flushInterval)
+	defer flushTicker.Stop()
+
+	for {
+		select {
+		case timed := <-r.packets:
+			if writer == nil {
+				writer = r.startFile(timed.at)
+				if writer == nil {
+					continue
+				}
+			}
+			if err := writer.Write(timed.at, timed.packet); err != nil {
+				slog.Error("Error writing to replay, starting a new one", "error", err)
+				closeWriter()
+			}
+		case <-rotateTicker.C:
+			// The next packet starts a new file
+			closeWriter()
+		case <-flushTicker.C:
+			if writer != nil {
+				if err := writer.Flush(); err != nil {
+					slog.Error("Error flushing replay", "error", err)
+				}
+			}
+		case <-r.stop:
+			return
+		}
+	}
+}
+
+func (r *Recorder) startFile(start time.Time) *Writer {
+	writer, name, err := r.library.create(start)
+	if err != nil {
+		slog.Error("Error creating replay", "name", name, "error", err)
+		return nil
+	}
+
+	for _, packet := range r.snapshot() {
+		if err := writer.Write(start, packet); err != nil {
+			slog.Error("Error writing snapshot to replay", "name", name, "error", err)
+			writer.Close()
+			return nil
+		}
+	}
+
+	slog.Info("Recording replay", "name", name)
+	return writer
+}
+
+// Stops recording, waiting for the file being written to be closed. Packets still waiting to
+// be written are lost. If Run hasn't started, it never will.
+func (r *Recorder) Close() {
+	r.stateMux.Lock()
+	r.closed = true
+	running := r.running
+	r.stateMux.Unlock()
+
+	r.closeOnce.Do(func() {
+		close(r.stop)
+	})
+	if running {
+		<-r.done
+	}
+}
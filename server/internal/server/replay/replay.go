@@ -0,0 +1,160 @@
+// Package replay records the packets that make up a game into compact files, and reads them
+// back with their timing so the game can be watched again later.
+//
+// A replay file is gzipped. It starts with a short header, followed by one frame per packet:
+// the milliseconds since the previous frame and the size of the packet, both as uvarints, then
+// the packet itself in protobuf.
+package replay
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"server/pkg/packets"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	header = "REPLAY1\n"
+	// Packets bigger than this can't have come from the server, so the file must be corrupt
+	maxPacketSize = 1 << 20
+)
+
+// A packet and when it was sent, relative to the start of the replay
+type Frame struct {
+	Offset time.Duration
+	Packet *packets.Packet
+}
+
+type Writer struct {
+	file    *os.File
+	gzip    *gzip.Writer
+	start   time.Time
+	last    time.Duration
+	scratch []byte
+}
+
+// Creates the file, with frame offsets counting from the start. Fails with fs.ErrExist rather
+// than overwriting a file that's already there.
+func Create(path string, start time.Time) (*Writer, error) {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		return nil, err
+	}
+
+	w := &Writer{
+		file:  file,
+		gzip:  gzip.NewWriter(file),
+		start: start,
+	}
+	if _, err := io.WriteString(w.gzip, header); err != nil {
+		file.Close()
+		return nil, err
+	}
+	return w, nil
+}
+
+// Packets have to be written in the order they were sent
+func (w *Writer) Write(at time.Time, packet *packets.Packet) error {
+	data, err := proto.Marshal(packet)
+	if err != nil {
+		return err
+	}
+
+	offset := max(at.Sub(w.start).Truncate(time.Millisecond), w.last)
+	w.scratch = binary.AppendUvarint(w.scratch[:0], uint64((offset - w.last).Milliseconds()))
+	w.scratch = binary.AppendUvarint(w.scratch, uint64(len(data)))
+	w.last = offset
+
+	if _, err := w.gzip.Write(w.scratch); err != nil {
+		return err
+	}
+	_, err = w.gzip.Write(data)
+	return err
+}
+
+// Pushes out everything written so far, so the file can be read while it's still being written
+func (w *Writer) Flush() error {
+	return w.gzip.Flush()
+}
+
+func (w *Writer) Close() error {
+	return errors.Join(w.gzip.Close(), w.file.Close())
+}
+
+type Reader struct {
+	file   *os.File
+	gzip   *gzip.Reader
+	reader *bufio.Reader
+	offset time.Duration
+}
+
+func Open(path string) (*Reader, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	gzipReader, err := gzip.NewReader(file)
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("not a replay file: %w", err)
+	}
+	r := &Reader{
+		file:   file,
+		gzip:   gzipReader,
+		reader: bufio.NewReader(gzipReader),
+	}
+
+	start := make([]byte, len(header))
+	if _, err := io.ReadFull(r.reader, start); err != nil || string(start) != header {
+		r.Close()
+		return nil, errors.New("not a replay file: bad header")
+	}
+	return r, nil
+}
+
+// Returns io.EOF once there are no more frames. A file cut short, like one that was still
+// being written when the server stopped, ends with io.ErrUnexpectedEOF.
+func (r *Reader) Next() (Frame, error) {
+	delta, err := binary.ReadUvarint(r.reader)
+	if err != nil {
+		return Frame{}, err
+	}
+	size, err := binary.ReadUvarint(r.reader)
+	if err != nil {
+		return Frame{}, unexpectedEOF(err)
+	}
+	if size > maxPacketSize {
+		return Frame{}, fmt.Errorf("packet of %d bytes is too big", size)
+	}
+
+	data := make([]byte, size)
+	if _, err := io.ReadFull(r.reader, data); err != nil {
+		return Frame{}, unexpectedEOF(err)
+	}
+	packet := &packets.Packet{}
+	if err := proto.Unmarshal(data, packet); err != nil {
+		return Frame{}, err
+	}
+
+	r.offset += time.Duration(delta) * time.Millisecond
+	return Frame{Offset: r.offset, Packet: packet}, nil
+}
+
+func (r *Reader) Close() error {
+	return errors.Join(r.gzip.Close(), r.file.Close())
+}
+
+func unexpectedEOF(err error) error {
+	if errors.Is(err, io.EOF) {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
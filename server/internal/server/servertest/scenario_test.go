@@ -0,0 +1,129 @@
+package servertest_test
+
+import (
+	"server/internal/server/clients"
+	"server/internal/server/objects"
+	"server/internal/server/servertest"
+	"server/internal/server/sim"
+	"server/pkg/packets"
+	"testing"
+	"time"
+)
+
+// Skips ahead to the client's own player, as it was when the client entered the game
+func expectOwnPlayer(t *testing.T, client *clients.LoopbackClient) *packets.PlayerMessage {
+	t.Helper()
+
+	for {
+		message := servertest.Expect[*packets.Packet_Player](t, client)
+		if message.Player.Id == client.Id() {
+			return message.Player
+		}
+	}
+}
+
+// Puts a spore right where the player is and has the client eat it
+func eatSporeUnderneath(t *testing.T, srv *servertest.Server, client *clients.LoopbackClient, player *packets.PlayerMessage, radius float64) uint64 {
+	t.Helper()
+
+	sporeId := srv.Hub.SharedGameObjects.Spores.Add(&objects.Spore{X: player.X, Y: player.Y, Radius: radius})
+	client.Inject(&packets.Packet_SporeConsumed{SporeConsumed: &packets.SporeConsumedMessage{SporeId: sporeId}})
+	return sporeId
+}
+
+// Alice and Bob join, Alice eats a spore, and Bob eats a spore so big he can reach Alice from
+// wherever they spawned. Returns once Alice knows she's been eaten.
+func aliceIsEatenByBob(t *testing.T, srv *servertest.Server) (alice, bob *clients.LoopbackClient, death *packets.DeathMessage) {
+	t.Helper()
+
+	alice = srv.Join(t, "alice", "secret")
+	alicePlayer := expectOwnPlayer(t, alice)
+	bob = srv.Join(t, "bob", "secret")
+	bobPlayer := expectOwnPlayer(t, bob)
+
+	sporeId := eatSporeUnderneath(t, srv, alice, alicePlayer, 10)
+	eaten := servertest.Expect[*packets.Packet_SporeConsumed](t, bob)
+	if eaten.SporeConsumed.SporeId != sporeId {
+		t.Fatalf("Bob saw spore %d eaten, want %d", eaten.SporeConsumed.SporeId, sporeId)
+	}
+
+	eatSporeUnderneath(t, srv, bob, bobPlayer, 10000)
+	servertest.Expect[*packets.Packet_SporeConsumed](t, alice)
+
+	// Nobody can be eaten while they've only just spawned
+	srv.Clock.Advance(sim.SpawnProtection + time.Second)
+
+	bob.Inject(&packets.Packet_PlayerConsumed{PlayerConsumed: &packets.PlayerConsumedMessage{PlayerId: alice.Id()}})
+	death = servertest.Expect[*packets.Packet_Death](t, alice).Death
+
+	return alice, bob, death
+}
+
+func TestEatDieAndMakeTheHiscores(t *testing.T) {
+	srv := servertest.NewServer(t)
+	alice, bob, death := aliceIsEatenByBob(t, srv)
+
+	if death.KillerId != bob.Id() || death.KillerName != "bob" {
+		t.Errorf("Alice was eaten by %q (%d), want bob (%d)", death.KillerName, death.KillerId, bob.Id())
+	}
+	startMass := uint64(sim.Score(sim.StartRadius))
+	if death.FinalMass <= startMass {
+		t.Errorf("Alice died with mass %d, want more than the %d she started with", death.FinalMass, startMass)
+	}
+	if want := (sim.SpawnProtection + time.Second).Seconds(); death.TimeAlive != want {
+		t.Errorf("Alice was alive for %vs, want %vs", death.TimeAlive, want)
+	}
+
+	alice.Inject(&packets.Packet_ReturnToMenu{ReturnToMenu: &packets.ReturnToMenuMessage{}})
+	alice.Inject(&packets.Packet_HiscoreBoardRequest{HiscoreBoardRequest: &packets.HiscoreBoardRequestMessage{}})
+	board := servertest.Expect[*packets.Packet_HiscoreBoard](t, alice).HiscoreBoard.Hiscores
+
+	if len(board) != 2 {
+		t.Fatalf("Hiscore board has %d entries, want 2", len(board))
+	}
+	if board[0].Name != "bob" || board[1].Name != "alice" {
+		t.Errorf("Hiscore board is %s then %s, want bob then alice", board[0].Name, board[1].Name)
+	}
+	if board[1].Score != death.FinalMass {
+		t.Errorf("Alice's hiscore is %d, want the %d she died with", board[1].Score, death.FinalMass)
+	}
+}
+
+func TestRespawnKeepsBestScore(t *testing.T) {
+	srv := servertest.NewServer(t)
+	alice, _, death := aliceIsEatenByBob(t, srv)
+
+	alice.Inject(&packets.Packet_RespawnRequest{RespawnRequest: &packets.RespawnRequestMessage{}})
+	player := expectOwnPlayer(t, alice)
+	if player.Radius != sim.StartRadius {
+		t.Errorf("Alice respawned with radius %v, want %v", player.Radius, sim.StartRadius)
+	}
+
+	// Leaving the game saves her best score, which mustn't have been lost along with her last life
+	alice.Inject(&packets.Packet_Disconnect{Disconnect: &packets.DisconnectMessage{Reason: "leaving"}})
+	alice.Inject(&packets.Packet_HiscoreBoardRequest{HiscoreBoardRequest: &packets.HiscoreBoardRequestMessage{}})
+	board := servertest.Expect[*packets.Packet_HiscoreBoard](t, alice).HiscoreBoard.Hiscores
+
+	for _, hiscore := range board {
+		if hiscore.Name == "alice" {
+			if hiscore.Score != death.FinalMass {
+				t.Errorf("Alice's hiscore is %d, want the %d from her first life", hiscore.Score, death.FinalMass)
+			}
+			return
+		}
+	}
+	t.Error("Alice isn't on the hiscore board")
+}
+
+func TestSpectateFromDeathScreen(t *testing.T) {
+	srv := servertest.NewServer(t)
+	alice, bob, _ := aliceIsEatenByBob(t, srv)
+
+	alice.Inject(&packets.Packet_SpectateRequest{SpectateRequest: &packets.SpectateRequestMessage{}})
+
+	// Bob is the only one left, so he's the leader
+	target := servertest.Expect[*packets.Packet_SpectateTarget](t, alice)
+	if target.SpectateTarget.PlayerId != bob.Id() {
+		t.Errorf("Alice is spectating %d, want bob (%d)", target.SpectateTarget.PlayerId, bob.Id())
+	}
+}
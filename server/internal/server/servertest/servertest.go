@@ -0,0 +1,136 @@
+// Package servertest runs a hub in the process, on an in-memory database, so whole flows like
+// registering, logging in, eating and dying can be scripted from tests with loopback clients.
+package servertest
+
+import (
+	"context"
+	"database/sql"
+	"server/internal/server"
+	"server/internal/server/clients"
+	"server/internal/server/sim"
+	"server/pkg/packets"
+	"testing"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+// How long to wait for the server to send something before failing
+var Timeout = 5 * time.Second
+
+type Server struct {
+	Hub *server.Hub
+	// The game runs on this rather than the wall clock, so tests decide when time passes
+	Clock *sim.ManualClock
+}
+
+// Starts a hub on a fresh in-memory database, which is shut down when the test finishes. The
+// game's randomness is seeded the same every time.
+func NewServer(tb testing.TB) *Server {
+	tb.Helper()
+
+	dbPool, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		tb.Fatalf("Error opening database: %v", err)
+	}
+	// Every connection to ":memory:" gets its own database, so stick to one
+	dbPool.SetMaxOpenConns(1)
+
+	clock := sim.NewManualClock(time.Unix(0, 0))
+	hub := server.NewHubWithDb(dbPool)
+	hub.Sim = sim.New(clock, 1)
+	go hub.Run()
+
+	tb.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), Timeout)
+		defer cancel()
+
+		if err := hub.Shutdown(ctx, "Test is over"); err != nil {
+			tb.Logf("Error shutting down hub: %v", err)
+		}
+	})
+
+	return &Server{Hub: hub, Clock: clock}
+}
+
+// Connects a new loopback client, returning once it has been given its id
+func (s *Server) Connect(tb testing.TB) *clients.LoopbackClient {
+	tb.Helper()
+
+	client := clients.NewLoopbackClient(s.Hub)
+	s.Hub.Register(client)
+	Expect[*packets.Packet_Id](tb, client)
+
+	return client
+}
+
+// Registers a user through the client, failing the test if the server doesn't accept it
+func (s *Server) Register(tb testing.TB, client *clients.LoopbackClient, username, password string) {
+	tb.Helper()
+
+	client.Inject(&packets.Packet_RegisterRequest{
+		RegisterRequest: &packets.RegisterRequestMessage{Username: username, Password: password},
+	})
+	expectOk(tb, client, "register "+username)
+}
+
+// Logs the client in, failing the test if the server doesn't accept it. The client is in game
+// once this returns.
+func (s *Server) Login(tb testing.TB, client *clients.LoopbackClient, username, password string) {
+	tb.Helper()
+
+	client.Inject(&packets.Packet_LoginRequest{
+		LoginRequest: &packets.LoginRequestMessage{Username: username, Password: password},
+	})
+	expectOk(tb, client, "log in as "+username)
+}
+
+// Connects a new client, registers it under the username and logs it in
+func (s *Server) Join(tb testing.TB, username, password string) *clients.LoopbackClient {
+	tb.Helper()
+
+	client := s.Connect(tb)
+	s.Register(tb, client, username, password)
+	s.Login(tb, client, username, password)
+
+	return client
+}
+
+// Skips over whatever else the client was sent until a message of type T arrives, failing the
+// test if none does in time
+func Expect[T packets.Msg](tb testing.TB, client *clients.LoopbackClient) T {
+	tb.Helper()
+
+	deadline := time.Now().Add(Timeout)
+	for {
+		packet, err := client.Next(time.Until(deadline))
+		if err != nil {
+			var zero T
+			tb.Fatalf("Expected %T: %v", zero, err)
+			return zero
+		}
+		if message, ok := packet.Msg.(T); ok {
+			return message
+		}
+	}
+}
+
+func expectOk(tb testing.TB, client *clients.LoopbackClient, action string) {
+	tb.Helper()
+
+	deadline := time.Now().Add(Timeout)
+	for {
+		packet, err := client.Next(time.Until(deadline))
+		if err != nil {
+			tb.Fatalf("Expected a response when trying to %s: %v", action, err)
+			return
+		}
+		switch message := packet.Msg.(type) {
+		case *packets.Packet_OkResponse:
+			return
+		case *packets.Packet_DenyResponse:
+			tb.Fatalf("Couldn't %s: %s", action, message.DenyResponse.Reason)
+			return
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package sim
+
+import (
+	"sync"
+	"time"
+)
+
+// Where the rules get the time from, so they can run on something other than the wall clock
+type Clock interface {
+	Now() time.Time
+}
+
+type SystemClock struct{}
+
+func (SystemClock) Now() time.Time {
+	return time.Now()
+}
+
+// A clock that only moves when it's told to, for stepping through the rules by hand. Safe to
+// share between goroutines.
+type ManualClock struct {
+	mux sync.Mutex
+	now time.Time
+}
+
+func NewManualClock(start time.Time) *ManualClock {
+	return &ManualClock{now: start}
+}
+
+func (c *ManualClock) Now() time.Time {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	return c.now
+}
+
+func (c *ManualClock) Advance(d time.Duration) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	c.now = c.now.Add(d)
+}
@@ -0,0 +1,30 @@
+package sim
+
+import "server/internal/server/objects"
+
+// Points the player where the client asked. Clients number their inputs so that player
+// updates can tell them which was the last one applied, letting them predict ahead of the
+// server and replay whatever it hasn't seen yet. An input numbered no later than one already
+// applied is stale, from arriving out of order over UDP, and is ignored. Returns whether the
+// input was applied.
+func ApplyDirection(player *objects.Player, direction float64, sequence uint32, clientTime float64) bool {
+	if sequence == 0 {
+		// The client doesn't number its inputs
+		player.Direction = direction
+		return true
+	}
+	if player.InputSequence != 0 && !isLaterInput(sequence, player.InputSequence) {
+		return false
+	}
+
+	player.Direction = direction
+	player.InputSequence = sequence
+	player.InputClientTime = clientTime
+	return true
+}
+
+// Sequences wrap around, with clients skipping zero, so an input is later than the 2^31
+// inputs before it
+func isLaterInput(sequence, last uint32) bool {
+	return int32(sequence-last) > 0
+}
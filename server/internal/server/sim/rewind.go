@@ -0,0 +1,77 @@
+package sim
+
+import (
+	"fmt"
+	"math"
+	"server/internal/server/objects"
+	"time"
+)
+
+const (
+	// However laggy a client is, its claims are only judged against where its player was up
+	// to this long ago
+	MaxRewind = 250 * time.Millisecond
+	// How much further back than the client's round trip to look, to cover jitter and the
+	// time between player updates
+	RewindSlack = 100 * time.Millisecond
+	// Enough positions to cover the longest rewind at the rate players are updated
+	HistorySize = 16
+)
+
+// How far back to look for what a client with this round trip time saw when it made a claim
+func RewindWindow(rtt time.Duration) time.Duration {
+	return min(max(rtt, 0), MaxRewind) + RewindSlack
+}
+
+// Whether the player came within reach of an object at any point since the given time, on
+// the path it took from then to where it is now
+func CheckReachSince(player *objects.Player, since time.Time, now time.Time, objX, objY, objRadius, buffer float64) error {
+	path := append(player.History.Since(since), position(player, now))
+
+	closest := math.Inf(1)
+	for i := range path {
+		from := path[max(i-1, 0)]
+		to := path[i]
+		gap := distanceToSegment(objX, objY, from, to) - max(from.Radius, to.Radius) - objRadius
+		closest = min(closest, gap)
+	}
+
+	if closest > buffer {
+		return fmt.Errorf("player is too far from the object (gap %f since %v ago, buffer %f)", closest, now.Sub(since), buffer)
+	}
+	return nil
+}
+
+// Whether the player came within reach of the other at any point since the given time,
+// comparing where each of them was at the same moments
+func CheckReachPlayerSince(player *objects.Player, other *objects.Player, since time.Time, now time.Time, buffer float64) error {
+	path := append(player.History.Since(since), position(player, now))
+
+	closest := math.Inf(1)
+	for _, ours := range path {
+		theirs, ok := other.History.At(ours.At)
+		if !ok || !ours.At.Before(now) {
+			theirs = position(other, now)
+		}
+		gap := math.Hypot(ours.X-theirs.X, ours.Y-theirs.Y) - ours.Radius - theirs.Radius
+		closest = min(closest, gap)
+	}
+
+	if closest > buffer {
+		return fmt.Errorf("player is too far from the other player (gap %f since %v ago, buffer %f)", closest, now.Sub(since), buffer)
+	}
+	return nil
+}
+
+func distanceToSegment(x, y float64, from, to objects.Position) float64 {
+	dx := to.X - from.X
+	dy := to.Y - from.Y
+	lengthSq := dx*dx + dy*dy
+	if lengthSq == 0 {
+		return math.Hypot(x-from.X, y-from.Y)
+	}
+
+	f := ((x-from.X)*dx + (y-from.Y)*dy) / lengthSq
+	f = min(max(f, 0), 1)
+	return math.Hypot(x-(from.X+f*dx), y-(from.Y+f*dy))
+}
@@ -0,0 +1,172 @@
+package sim
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"server/internal/server/objects"
+	"time"
+)
+
+const (
+	StartRadius          = 20.0
+	SpawnProtection      = 3 * time.Second
+	BaseSpeed            = 150.0
+	SpeedBoostMultiplier = 1.5
+	// How far beyond the player's edge a magnet pulls in spores
+	MagnetRange = 150.0
+	// How far past a player's edge it may claim to have reached something. Lag is made up
+	// for by rewinding, so this only has to cover rounding and the odd late update.
+	ReachBuffer = 3.0
+	// How far clear of a spore a player has to have been able to move before eating one it dropped
+	DropClearance = 10.0
+	// How many times more massive than another player a player has to be to consume them
+	ConsumeMassRatio = 1.5
+	PowerUpRadius    = 15.0
+
+	// Players any smaller than this don't shed spores
+	minDropRadius = 10.0
+)
+
+// What happened to a player over one step of the game
+type StepResult struct {
+	// A spore the player shed, already taken off its mass, for the caller to add to the world
+	Dropped *objects.Spore
+}
+
+func RadToMass(radius float64) float64 {
+	return math.Pi * radius * radius
+}
+
+func MassToRad(mass float64) float64 {
+	return math.Sqrt(mass / math.Pi)
+}
+
+// The radius a player of this radius ends up with after gaining massDiff, or losing it if negative
+func Grow(radius float64, massDiff float64) float64 {
+	return MassToRad(RadToMass(radius) + massDiff)
+}
+
+func Score(radius float64) int64 {
+	return int64(math.Round(RadToMass(radius)))
+}
+
+// Starts the player's life somewhere safe, protected for a while
+func Spawn(player *objects.Player, now time.Time, rng *rand.Rand, players *objects.SharedCollection[*objects.Player], spores *objects.SharedCollection[*objects.Spore]) {
+	player.Speed = BaseSpeed
+	player.Radius = StartRadius
+	player.X, player.Y = objects.SafeSpawnCoords(rng, player.Radius, players, spores)
+	player.SpawnProtectedUntil = now.Add(SpawnProtection)
+
+	// A fresh history, so the player isn't thought to have passed between where it died and here
+	player.History = objects.NewPositionHistory(HistorySize)
+	player.History.Record(position(player, now))
+}
+
+func position(player *objects.Player, now time.Time) objects.Position {
+	return objects.Position{At: now, X: player.X, Y: player.Y, Radius: player.Radius}
+}
+
+// Moves the player along its direction for delta seconds, now and then shedding a spore. The
+// more spores the world is meant to hold, the less often players shed them, and in a world
+// meant to hold none they shed none.
+func Step(player *objects.Player, delta float64, now time.Time, rng *rand.Rand, maxSpores int) StepResult {
+	player.ExpireEffects(now)
+	player.Speed = BaseSpeed
+	if player.HasEffect(objects.PowerUpSpeedBoost, now) {
+		player.Speed *= SpeedBoostMultiplier
+	}
+
+	player.X += player.Speed * math.Cos(player.Direction) * delta
+	player.Y += player.Speed * math.Sin(player.Direction) * delta
+
+	result := StepResult{}
+	probability := player.Radius / float64(maxSpores*5)
+	if maxSpores > 0 && rng.Float64() < probability && player.Radius > minDropRadius {
+		result.Dropped = &objects.Spore{
+			X:         player.X,
+			Y:         player.Y,
+			Radius:    min(5+player.Radius/50, 15),
+			DroppedBy: player,
+			DroppedAt: now,
+		}
+		player.Radius = Grow(player.Radius, -RadToMass(result.Dropped.Radius))
+	}
+
+	player.History.Record(position(player, now))
+	return result
+}
+
+// Whether the player is close enough to an object to have consumed it, give or take buffer
+func CheckReach(player *objects.Player, objX, objY, objRadius, buffer float64) error {
+	dx := player.X - objX
+	dy := player.Y - objY
+	distSq := dx*dx + dy*dy
+
+	thresholdDist := player.Radius + buffer + objRadius
+	thresholdDistSq := thresholdDist * thresholdDist
+
+	if distSq > thresholdDistSq {
+		return fmt.Errorf("player is too far from the object (distSq %f, thresholdSq %f)", distSq, thresholdDistSq)
+	}
+
+	return nil
+}
+
+// Players can't eat a spore they've just dropped until they could have moved clear of it
+func CheckDropCooldown(player *objects.Player, spore *objects.Spore, buffer float64, now time.Time) error {
+	if spore.DroppedBy != player {
+		return nil
+	}
+
+	minAcceptableDistance := spore.Radius + player.Radius + buffer
+	minAcceptableTime := time.Duration(minAcceptableDistance/player.Speed*1000) * time.Millisecond
+	if since := now.Sub(spore.DroppedAt); since < minAcceptableTime {
+		return fmt.Errorf("player dropped the spore too recently (time %v, min acceptable time: %v)", since, minAcceptableTime)
+	}
+	return nil
+}
+
+// Whether the player is allowed to consume the other, leaving aside whether it can reach them
+func CheckConsume(player *objects.Player, other *objects.Player, now time.Time) error {
+	if other.IsSpawnProtected(now) {
+		return errors.New("the other player is spawn protected")
+	}
+
+	if other.HasEffect(objects.PowerUpShield, now) {
+		return errors.New("the other player is shielded")
+	}
+
+	if RadToMass(player.Radius) <= RadToMass(other.Radius)*ConsumeMassRatio {
+		return fmt.Errorf("player not massive enough to consume the other player (radius %f, other radius %f)", player.Radius, other.Radius)
+	}
+
+	return nil
+}
+
+// Whether a player with a magnet pulls in the spore
+func Attracts(player *objects.Player, spore *objects.Spore, now time.Time) bool {
+	return CheckReach(player, spore.X, spore.Y, spore.Radius, MagnetRange) == nil &&
+		CheckDropCooldown(player, spore, DropClearance, now) == nil
+}
+
+func NewSpore(rng *rand.Rand, players *objects.SharedCollection[*objects.Player], spores *objects.SharedCollection[*objects.Spore]) *objects.Spore {
+	radius := max(10+rng.NormFloat64()*3, 5)
+	x, y := objects.SpawnCoords(rng, radius, players, spores)
+	return &objects.Spore{
+		X:      x,
+		Y:      y,
+		Radius: radius,
+	}
+}
+
+func NewPowerUp(rng *rand.Rand, players *objects.SharedCollection[*objects.Player], spores *objects.SharedCollection[*objects.Spore]) *objects.PowerUp {
+	x, y := objects.SpawnCoords(rng, PowerUpRadius, players, spores)
+	return &objects.PowerUp{
+		X:      x,
+		Y:      y,
+		Radius: PowerUpRadius,
+		Kind:   objects.PowerUpKinds[rng.IntN(len(objects.PowerUpKinds))],
+	}
+}
@@ -0,0 +1,54 @@
+// Package sim holds the game rules, free of any networking. Rules take the world as it is,
+// the time and a source of randomness, and return what happened for the caller to apply and
+// tell clients about. Run on a ManualClock with a fixed seed, the same inputs always play out
+// the same way.
+package sim
+
+import (
+	"math/rand/v2"
+	"sync"
+	"time"
+)
+
+// The clock and randomness the rules run on
+type Sim struct {
+	Clock Clock
+	// Safe to share between goroutines, though then the order they take numbers in decides
+	// who gets which
+	Rand *rand.Rand
+	seed uint64
+}
+
+func New(clock Clock, seed uint64) *Sim {
+	return newSim(clock, seed, 0)
+}
+
+func newSim(clock Clock, seed uint64, stream uint64) *Sim {
+	return &Sim{
+		Clock: clock,
+		Rand:  rand.New(&lockedSource{source: rand.NewPCG(seed, stream)}),
+		seed:  seed,
+	}
+}
+
+// Shares the clock but has its own stream of random numbers, so that whatever takes numbers
+// from it gets the same ones no matter what else is going on. Forking the same stream twice
+// gives the same numbers twice.
+func (s *Sim) Fork(stream uint64) *Sim {
+	return newSim(s.Clock, s.seed, stream)
+}
+
+func (s *Sim) Now() time.Time {
+	return s.Clock.Now()
+}
+
+type lockedSource struct {
+	mux    sync.Mutex
+	source rand.Source
+}
+
+func (s *lockedSource) Uint64() uint64 {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	return s.source.Uint64()
+}
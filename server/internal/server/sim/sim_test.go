@@ -0,0 +1,382 @@
+package sim
+
+import (
+	"server/internal/server/objects"
+	"testing"
+	"time"
+)
+
+var start = time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+// Spawns a player in an empty world and steps it the given number of times, a tick apart,
+// returning where it ended up and how many spores it shed along the way
+func play(s *Sim, clock *ManualClock, steps int, maxSpores int) (*objects.Player, int) {
+	player := &objects.Player{Direction: 0.5}
+	Spawn(player, s.Now(), s.Rand, objects.NewSharedCollection[*objects.Player](), objects.NewSharedCollection[*objects.Spore]())
+	// Big enough to shed spores now and then
+	player.Radius = 200
+
+	dropped := 0
+	for range steps {
+		clock.Advance(50 * time.Millisecond)
+		if Step(player, 0.05, s.Now(), s.Rand, maxSpores).Dropped != nil {
+			dropped++
+		}
+	}
+	return player, dropped
+}
+
+func TestSameSeedPlaysTheSame(t *testing.T) {
+	firstClock, secondClock := NewManualClock(start), NewManualClock(start)
+	first, firstDropped := play(New(firstClock, 1).Fork(7), firstClock, 500, 10)
+	second, secondDropped := play(New(secondClock, 1).Fork(7), secondClock, 500, 10)
+
+	if firstDropped == 0 {
+		t.Fatal("No spores were shed, so there's nothing to compare")
+	}
+	if first.X != second.X || first.Y != second.Y || first.Radius != second.Radius || firstDropped != secondDropped {
+		t.Errorf("Same seed played out differently: (%v, %v) radius %v with %d shed, then (%v, %v) radius %v with %d shed",
+			first.X, first.Y, first.Radius, firstDropped, second.X, second.Y, second.Radius, secondDropped)
+	}
+}
+
+func TestForksDrawDifferentNumbers(t *testing.T) {
+	s := New(NewManualClock(start), 1)
+	if s.Fork(1).Rand.Uint64() == s.Fork(2).Rand.Uint64() {
+		t.Error("Forks on different streams drew the same number")
+	}
+	if s.Fork(3).Rand.Uint64() != s.Fork(3).Rand.Uint64() {
+		t.Error("Forks on the same stream drew different numbers")
+	}
+}
+
+func TestNoSporesShedWhenWorldHoldsNone(t *testing.T) {
+	clock := NewManualClock(start)
+	if _, dropped := play(New(clock, 1), clock, 500, 0); dropped != 0 {
+		t.Errorf("Shed %d spores in a world meant to hold none", dropped)
+	}
+}
+
+func TestSpawnProtectionWearsOff(t *testing.T) {
+	clock := NewManualClock(start)
+	s := New(clock, 1)
+	player := &objects.Player{}
+	Spawn(player, s.Now(), s.Rand, objects.NewSharedCollection[*objects.Player](), objects.NewSharedCollection[*objects.Spore]())
+
+	clock.Advance(SpawnProtection - time.Millisecond)
+	if !player.IsSpawnProtected(s.Now()) {
+		t.Error("Spawn protection wore off early")
+	}
+	clock.Advance(time.Millisecond)
+	if player.IsSpawnProtected(s.Now()) {
+		t.Error("Spawn protection didn't wear off")
+	}
+}
+
+func TestStaleInputsAreIgnored(t *testing.T) {
+	player := &objects.Player{}
+	if !ApplyDirection(player, 1, 2, 0) {
+		t.Fatal("First input wasn't applied")
+	}
+	if ApplyDirection(player, 2, 1, 0) || player.Direction != 1 {
+		t.Error("Input older than the last one applied was applied")
+	}
+	// Just past the wrap, skipping zero
+	player.InputSequence = ^uint32(0)
+	if !ApplyDirection(player, 3, 1, 0) || player.Direction != 3 {
+		t.Error("Input after the sequence wrapped around wasn't applied")
+	}
+}
+
+func TestRewindWindow(t *testing.T) {
+	tests := []struct {
+		rtt  time.Duration
+		want time.Duration
+	}{
+		{rtt: -time.Second, want: RewindSlack},
+		{rtt: 0, want: RewindSlack},
+		{rtt: 100 * time.Millisecond, want: 100*time.Millisecond + RewindSlack},
+		{rtt: time.Second, want: MaxRewind + RewindSlack},
+	}
+	for _, test := range tests {
+		if got := RewindWindow(test.rtt); got != test.want {
+			t.Errorf("RewindWindow(%v) = %v, want %v", test.rtt, got, test.want)
+		}
+	}
+}
+
+// A player that has moved right along the x axis from the origin at BaseSpeed for a second,
+// with its position recorded every tick
+func movedPlayer(clock *ManualClock) *objects.Player {
+	player := &objects.Player{
+		Radius:  StartRadius,
+		History: objects.NewPositionHistory(HistorySize),
+	}
+	player.History.Record(position(player, clock.Now()))
+	for range 20 {
+		clock.Advance(50 * time.Millisecond)
+		Step(player, 0.05, clock.Now(), nil, 0)
+	}
+	return player
+}
+
+func TestCheckReachSince(t *testing.T) {
+	tests := []struct {
+		name string
+		rtt  time.Duration
+		// Where the object is along the x axis
+		objX      float64
+		noHistory bool
+		reached   bool
+	}{
+		// The player was over x = 70 to 120 between 0.47s and 0.8s in
+		{name: "claim inside the window", rtt: 150 * time.Millisecond, objX: 95, reached: true},
+		{name: "claim from before the window", rtt: 0, objX: 95, reached: false},
+		// Only reachable in the first 0.3s, which is further back than any client gets
+		{name: "claim older than the longest rewind", rtt: time.Second, objX: 20, reached: false},
+		{name: "within reach now", rtt: 0, objX: 170, reached: true},
+		{name: "empty history, out of reach now", rtt: 150 * time.Millisecond, objX: 95, noHistory: true, reached: false},
+		{name: "empty history, within reach now", rtt: 150 * time.Millisecond, objX: 170, noHistory: true, reached: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			clock := NewManualClock(start)
+			player := movedPlayer(clock)
+			if test.noHistory {
+				player.History = nil
+			}
+
+			now := clock.Now()
+			err := CheckReachSince(player, now.Add(-RewindWindow(test.rtt)), now, test.objX, 0, 5, ReachBuffer)
+			if reached := err == nil; reached != test.reached {
+				t.Errorf("Reached the object: %v, want %v (%v)", reached, test.reached, err)
+			}
+		})
+	}
+}
+
+func TestCheckReachPlayerSince(t *testing.T) {
+	tests := []struct {
+		name      string
+		rtt       time.Duration
+		noHistory bool
+		reached   bool
+	}{
+		{name: "claim inside the window", rtt: 150 * time.Millisecond, reached: true},
+		{name: "claim from before the window", rtt: 0, reached: false},
+		{name: "empty history", rtt: 150 * time.Millisecond, noHistory: true, reached: false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			clock := NewManualClock(start)
+			// The other player sits at x = 95 until it moves well away, just before now
+			other := &objects.Player{Radius: 5, X: 95, History: objects.NewPositionHistory(HistorySize)}
+			other.History.Record(position(other, clock.Now()))
+			player := movedPlayer(clock)
+			other.History.Record(position(other, clock.Now().Add(-time.Millisecond)))
+			other.X = 400
+			if test.noHistory {
+				other.History = nil
+			}
+
+			now := clock.Now()
+			err := CheckReachPlayerSince(player, other, now.Add(-RewindWindow(test.rtt)), now, ReachBuffer)
+			if reached := err == nil; reached != test.reached {
+				t.Errorf("Reached the other player: %v, want %v (%v)", reached, test.reached, err)
+			}
+		})
+	}
+}
+
+func TestPositionHistory(t *testing.T) {
+	history := objects.NewPositionHistory(4)
+	for i := range 6 {
+		history.Record(objects.Position{At: start.Add(time.Duration(i) * time.Second), X: float64(i * 100)})
+	}
+
+	// Only the last four are kept, oldest first
+	since := history.Since(start)
+	if len(since) != 4 || since[0].X != 200 || since[3].X != 500 {
+		t.Errorf("Since the start: %+v, want the positions at x = 200 to 500", since)
+	}
+	// Starting with the last one before the time, so the path from then on is complete
+	if since := history.Since(start.Add(3500 * time.Millisecond)); len(since) != 3 || since[0].X != 300 {
+		t.Errorf("Since 3.5s in: %+v, want the positions at x = 300 to 500", since)
+	}
+
+	tests := []struct {
+		at   time.Duration
+		want float64
+	}{
+		{at: 3250 * time.Millisecond, want: 325},
+		{at: 4 * time.Second, want: 400},
+		// Outside the history, the nearest position there is
+		{at: 0, want: 200},
+		{at: time.Minute, want: 500},
+	}
+	for _, test := range tests {
+		position, ok := history.At(start.Add(test.at))
+		if !ok || position.X != test.want {
+			t.Errorf("At %v in: x = %v (%v), want %v", test.at, position.X, ok, test.want)
+		}
+	}
+
+	var empty *objects.PositionHistory
+	if _, ok := empty.At(start); ok {
+		t.Error("Found a position in an empty history")
+	}
+}
+
+func TestCheckConsume(t *testing.T) {
+	tests := []struct {
+		name        string
+		radius      float64
+		otherRadius float64
+		// How long the other player has been in the game
+		alive    time.Duration
+		shielded bool
+		allowed  bool
+	}{
+		{name: "massive enough", radius: 25, otherRadius: 20, alive: time.Minute, allowed: true},
+		{name: "not massive enough", radius: 24, otherRadius: 20, alive: time.Minute, allowed: false},
+		{name: "spawn protected", radius: 100, otherRadius: 20, alive: SpawnProtection - time.Millisecond, allowed: false},
+		{name: "spawn protection worn off", radius: 100, otherRadius: 20, alive: SpawnProtection, allowed: true},
+		{name: "shielded", radius: 100, otherRadius: 20, alive: time.Minute, shielded: true, allowed: false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			clock := NewManualClock(start)
+			player := &objects.Player{Radius: test.radius}
+			other := &objects.Player{Radius: test.otherRadius, SpawnProtectedUntil: clock.Now().Add(SpawnProtection)}
+			clock.Advance(test.alive)
+			if test.shielded {
+				other.AddEffect(objects.PowerUpShield, clock.Now())
+			}
+
+			err := CheckConsume(player, other, clock.Now())
+			if allowed := err == nil; allowed != test.allowed {
+				t.Errorf("Allowed to consume: %v, want %v (%v)", allowed, test.allowed, err)
+			}
+		})
+	}
+}
+
+func TestCheckDropCooldown(t *testing.T) {
+	player := &objects.Player{Radius: StartRadius, Speed: BaseSpeed}
+	// It takes (5 + 20 + 10) / 150 seconds, about 233ms, to get clear of the spore
+	tests := []struct {
+		name      string
+		droppedBy *objects.Player
+		since     time.Duration
+		allowed   bool
+	}{
+		{name: "just dropped", droppedBy: player, since: 0, allowed: false},
+		{name: "not clear yet", droppedBy: player, since: 200 * time.Millisecond, allowed: false},
+		{name: "clear of it", droppedBy: player, since: 240 * time.Millisecond, allowed: true},
+		{name: "dropped by someone else", droppedBy: &objects.Player{}, since: 0, allowed: true},
+		{name: "never dropped", since: 0, allowed: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			clock := NewManualClock(start)
+			spore := &objects.Spore{Radius: 5, DroppedBy: test.droppedBy, DroppedAt: clock.Now()}
+			clock.Advance(test.since)
+
+			err := CheckDropCooldown(player, spore, DropClearance, clock.Now())
+			if allowed := err == nil; allowed != test.allowed {
+				t.Errorf("Allowed to eat the spore: %v, want %v (%v)", allowed, test.allowed, err)
+			}
+		})
+	}
+}
+
+func TestAttracts(t *testing.T) {
+	player := &objects.Player{Radius: StartRadius, Speed: BaseSpeed}
+	tests := []struct {
+		name      string
+		sporeX    float64
+		droppedBy *objects.Player
+		since     time.Duration
+		attracted bool
+	}{
+		{name: "in range", sporeX: 100, attracted: true},
+		{name: "out of range", sporeX: 200, attracted: false},
+		{name: "just dropped", sporeX: 100, droppedBy: player, attracted: false},
+		{name: "dropped a while ago", sporeX: 100, droppedBy: player, since: time.Second, attracted: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			clock := NewManualClock(start)
+			spore := &objects.Spore{X: test.sporeX, Radius: 5, DroppedBy: test.droppedBy, DroppedAt: clock.Now()}
+			clock.Advance(test.since)
+
+			if attracted := Attracts(player, spore, clock.Now()); attracted != test.attracted {
+				t.Errorf("Attracted the spore: %v, want %v", attracted, test.attracted)
+			}
+		})
+	}
+}
+
+func TestPowerUpsStack(t *testing.T) {
+	duration := objects.PowerUpShield.Duration()
+	tests := []struct {
+		name string
+		// How long after the last pick-up each is picked up
+		pickUps   []time.Duration
+		remaining time.Duration
+	}{
+		{name: "once", pickUps: []time.Duration{0}, remaining: duration},
+		{name: "twice at once", pickUps: []time.Duration{0, 0}, remaining: 2 * duration},
+		{name: "again halfway through", pickUps: []time.Duration{0, duration / 2}, remaining: duration * 3 / 2},
+		{name: "again once run out", pickUps: []time.Duration{0, 2 * duration}, remaining: duration},
+		{name: "up to three times over", pickUps: []time.Duration{0, 0, 0, 0}, remaining: 3 * duration},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			clock := NewManualClock(start)
+			player := &objects.Player{}
+			for _, wait := range test.pickUps {
+				clock.Advance(wait)
+				player.AddEffect(objects.PowerUpShield, clock.Now())
+			}
+
+			if remaining := player.EffectRemaining(objects.PowerUpShield, clock.Now()); remaining != test.remaining {
+				t.Errorf("Shield has %v left, want %v", remaining, test.remaining)
+			}
+		})
+	}
+}
+
+func TestPowerUpsRunOut(t *testing.T) {
+	clock := NewManualClock(start)
+	player := &objects.Player{Radius: StartRadius}
+	player.AddEffect(objects.PowerUpSpeedBoost, clock.Now())
+	player.AddEffect(objects.PowerUpShield, clock.Now())
+
+	Step(player, 0.05, clock.Now(), nil, 0)
+	if player.Speed != BaseSpeed*SpeedBoostMultiplier {
+		t.Errorf("Boosted speed is %v, want %v", player.Speed, BaseSpeed*SpeedBoostMultiplier)
+	}
+
+	// The shield runs out first
+	clock.Advance(objects.PowerUpShield.Duration())
+	Step(player, 0.05, clock.Now(), nil, 0)
+	if player.HasEffect(objects.PowerUpShield, clock.Now()) {
+		t.Error("Shield didn't run out")
+	}
+	if _, kept := player.Effects[objects.PowerUpShield]; kept {
+		t.Error("Shield was kept after running out")
+	}
+	if !player.HasEffect(objects.PowerUpSpeedBoost, clock.Now()) {
+		t.Error("Speed boost ran out along with the shield")
+	}
+
+	clock.Advance(objects.PowerUpSpeedBoost.Duration() - objects.PowerUpShield.Duration())
+	Step(player, 0.05, clock.Now(), nil, 0)
+	if len(player.Effects) != 0 {
+		t.Errorf("Effects %v were kept after running out", player.Effects)
+	}
+	if player.Speed != BaseSpeed {
+		t.Errorf("Speed is %v once the boost ran out, want %v", player.Speed, BaseSpeed)
+	}
+}
@@ -2,8 +2,7 @@ package states
 
 import (
 	"context"
-	"fmt"
-	"log"
+	"log/slog"
 	"server/internal/server"
 	"server/internal/server/db"
 	"server/pkg/packets"
@@ -11,7 +10,7 @@ import (
 
 type BrowsingHiscores struct {
 	client  server.ClientInterfacer
-	logger  *log.Logger
+	logger  *slog.Logger
 	queries *db.Queries
 	dbCtx   context.Context
 }
@@ -22,8 +21,7 @@ func (b *BrowsingHiscores) Name() string {
 
 func (b *BrowsingHiscores) SetClient(client server.ClientInterfacer) {
 	b.client = client
-	loggingPrefix := fmt.Sprintf("Client %d [%s]: ", client.Id(), b.Name())
-	b.logger = log.New(log.Writer(), loggingPrefix, log.LstdFlags)
+	b.logger = client.Logger().With("state", b.Name())
 	b.queries = client.DbTx().Queries
 	b.dbCtx = client.DbTx().Ctx
 }
@@ -46,20 +44,30 @@ func (b *BrowsingHiscores) OnExit() {
 }
 
 func (b *BrowsingHiscores) handleFinishedBrowsingHiscoresMessage(senderId uint64, message *packets.Packet_FinishedBrowsingHiscores) {
+	if senderId != b.client.Id() {
+		b.logger.Warn("Received finished browsing hiscores from another client", "sender_id", senderId)
+		return
+	}
+
 	b.client.SetState(&Connected{})
 }
 func (b *BrowsingHiscores) handleSearchHiscore(senderId uint64, message *packets.Packet_SearchHiscore) {
+	if senderId != b.client.Id() {
+		b.logger.Warn("Received hiscore search from another client", "sender_id", senderId)
+		return
+	}
+
 	player, err := b.queries.GetPlayerByName(b.dbCtx, message.SearchHiscore.Name)
 
 	if err != nil {
-		b.logger.Printf("Error getting player %s: %v", message.SearchHiscore.Name, err)
+		b.logger.Info("Error getting player", "name", message.SearchHiscore.Name, "error", err)
 		b.client.SocketSend(packets.NewDenyResponse("No player found with that name"))
 		return
 	}
 
 	playerRank, err := b.queries.GetPlayerRank(b.dbCtx, player.ID)
 	if err != nil {
-		b.logger.Printf("Error getting rank of player %s: %v", message.SearchHiscore.Name, err)
+		b.logger.Error("Error getting rank of player", "name", message.SearchHiscore.Name, "error", err)
 		b.client.SocketSend(packets.NewDenyResponse("Player is unranked"))
 		return
 	}
@@ -77,7 +85,7 @@ func (b *BrowsingHiscores) sendTopScores(limit int64, offset int64) {
 	})
 
 	if err != nil {
-		b.logger.Printf("Error getting top %d scores from rank %d: %v", limit, offset, err)
+		b.logger.Error("Error getting top scores", "limit", limit, "offset", offset, "error", err)
 		b.client.SocketSend(packets.NewDenyResponse("Failed to get top scores - please try again later"))
 		return
 	}
@@ -2,21 +2,26 @@ package states
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"fmt"
-	"log"
+	"io/fs"
+	"log/slog"
 	"server/internal/server"
 	"server/internal/server/db"
+	"server/internal/server/metrics"
 	"server/internal/server/objects"
+	"server/internal/server/replay"
 	"server/pkg/packets"
 	"strings"
+	"time"
 
 	"golang.org/x/crypto/bcrypt"
 )
 
 type Connected struct {
 	client  server.ClientInterfacer
-	logger  *log.Logger
+	logger  *slog.Logger
 	queries *db.Queries
 	dbCtx   context.Context
 }
@@ -28,8 +33,9 @@ func (c *Connected) Name() string {
 
 func (c *Connected) SetClient(client server.ClientInterfacer) {
 	c.client = client
-	loggingPrefix := fmt.Sprintf("Client %d [%s]: ", client.Id(), c.Name())
-	c.logger = log.New(log.Writer(), loggingPrefix, log.LstdFlags)
+	// Back at the menu, nobody is logged in anymore
+	client.Identify(nil)
+	c.logger = client.Logger().With("state", c.Name())
 	c.queries = client.DbTx().Queries
 	c.dbCtx = client.DbTx().Ctx
 }
@@ -46,6 +52,10 @@ func (c *Connected) HandleMessage(senderId uint64, message packets.Msg) {
 		c.handleRegisterRequest(senderId, message)
 	case *packets.Packet_HiscoreBoardRequest:
 		c.handleHiscoreBoardRequest(senderId, message)
+	case *packets.Packet_SpectateRequest:
+		c.handleSpectateRequest(senderId, message)
+	case *packets.Packet_ReplayRequest:
+		c.handleReplayRequest(senderId, message)
 	}
 }
 
@@ -55,7 +65,7 @@ func (c *Connected) OnExit() {
 
 func (c *Connected) handleLoginRequest(senderId uint64, message *packets.Packet_LoginRequest) {
 	if senderId != c.client.Id() {
-		c.logger.Printf("Received login request from another client (id %d)", senderId)
+		c.logger.Warn("Received login request from another client", "sender_id", senderId)
 		return
 	}
 	username := message.LoginRequest.Username
@@ -64,43 +74,61 @@ func (c *Connected) handleLoginRequest(senderId uint64, message *packets.Packet_
 
 	user, err := c.queries.GetUserByUsername(c.dbCtx, strings.ToLower(username))
 	if err != nil {
-		c.logger.Printf("Error getting user by username: %v", err)
+		c.logger.Info("Error getting user by username", "username", username, "error", err)
 		c.client.SocketSend(genericFailMessage)
 		return
 	}
 
+	start := time.Now()
 	err = bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(message.LoginRequest.Password))
+	metrics.BcryptSeconds.ObserveSince("compare", start)
 
 	if err != nil {
-		c.logger.Printf("Incorrect password for user %s", username)
+		c.logger.Info("Incorrect password", "username", username)
+		c.client.SocketSend(genericFailMessage)
+		return
+	}
+
+	// Only once the password checks out, so the reason isn't shown to just anyone
+	ban, err := c.queries.GetActiveUserBan(c.dbCtx, db.GetActiveUserBanParams{
+		UserID: user.ID,
+		Now:    server.BanTime(c.client.Sim().Now()),
+	})
+	if err == nil {
+		c.logger.Info("Banned user tried to log in", "username", username, "ban_id", ban.ID)
+		c.client.SocketSend(packets.NewDenyResponse(server.DescribeBan(ban.Reason, ban.ExpiresAt)))
+		return
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		c.logger.Error("Error checking user bans", "username", username, "error", err)
 		c.client.SocketSend(genericFailMessage)
 		return
 	}
 
 	player, err := c.queries.GetPlayerByUserId(c.dbCtx, user.ID)
 	if err != nil {
-		c.logger.Printf("Error getting player for user %s: %v", username, err)
+		c.logger.Error("Error getting player for user", "username", username, "error", err)
 		c.client.SocketSend(genericFailMessage)
 		return
 	}
 
-	c.logger.Printf("User %s logged in successfully", username)
+	loggedIn := &objects.Player{
+		Name:      player.Name,
+		DbId:      player.ID,
+		BestScore: player.BestScore,
+		Color:     int32(player.Color),
+	}
+	c.client.Identify(loggedIn)
+	c.logger.Info("User logged in successfully", "username", username)
 	c.client.SocketSend(packets.NewOkResponse())
 
-	c.client.SetState(&InGame{
-		player: &objects.Player{
-			Name:      player.Name,
-			DbId:      player.ID,
-			BestScore: player.BestScore,
-			Color:     int32(player.Color),
-		},
-	})
+	c.client.SetState(&InGame{player: loggedIn})
 
 }
 
 func (c *Connected) handleRegisterRequest(senderId uint64, message *packets.Packet_RegisterRequest) {
 	if senderId != c.client.Id() {
-		c.logger.Printf("Received login request from another client (id %d)", senderId)
+		c.logger.Warn("Received register request from another client", "sender_id", senderId)
 		return
 	}
 
@@ -109,24 +137,26 @@ func (c *Connected) handleRegisterRequest(senderId uint64, message *packets.Pack
 	err := validateUsername(username)
 	if err != nil {
 		reason := fmt.Sprintf("Invalid username: %v", err)
-		c.logger.Println(reason)
+		c.logger.Info("Invalid username", "username", username, "error", err)
 		c.client.SocketSend(packets.NewDenyResponse(reason))
 		return
 	}
 
 	_, err = c.queries.GetUserByUsername(c.dbCtx, strings.ToLower(username))
 	if err == nil {
-		c.logger.Printf("User already exists: %v", err)
+		c.logger.Info("User already exists", "username", username)
 		c.client.SocketSend(packets.NewDenyResponse("User already exists"))
 		return
 	}
 
 	genericFailMessage := packets.NewDenyResponse("Failed to register user (internal server error) - please try again later")
 
+	start := time.Now()
 	passwordHash, err := bcrypt.GenerateFromPassword([]byte(message.RegisterRequest.Password), bcrypt.DefaultCost)
+	metrics.BcryptSeconds.ObserveSince("generate", start)
 
 	if err != nil {
-		c.logger.Printf("Failed to hash password: %v", err)
+		c.logger.Error("Failed to hash password", "error", err)
 		c.client.SocketSend(genericFailMessage)
 		return
 	}
@@ -137,7 +167,7 @@ func (c *Connected) handleRegisterRequest(senderId uint64, message *packets.Pack
 	})
 
 	if err != nil {
-		c.logger.Printf("Failed to create user: %v", err)
+		c.logger.Error("Failed to create user", "username", username, "error", err)
 		c.client.SocketSend(genericFailMessage)
 		return
 	}
@@ -149,20 +179,62 @@ func (c *Connected) handleRegisterRequest(senderId uint64, message *packets.Pack
 	})
 
 	if err != nil {
-		c.logger.Printf("Failed to create player for user %s: %v\n", username, err)
+		c.logger.Error("Failed to create player for user", "username", username, "error", err)
 		c.client.SocketSend(genericFailMessage)
 		return
 	}
 
-	c.logger.Printf("User %s registered successfully!\n", username)
+	c.logger.Info("User registered successfully", "username", username)
 
 	c.client.SocketSend(packets.NewOkResponse())
 }
 
 func (c *Connected) handleHiscoreBoardRequest(senderId uint64, message *packets.Packet_HiscoreBoardRequest) {
+	if senderId != c.client.Id() {
+		c.logger.Warn("Received hiscore board request from another client", "sender_id", senderId)
+		return
+	}
+
 	c.client.SetState(&BrowsingHiscores{})
 }
 
+func (c *Connected) handleSpectateRequest(senderId uint64, message *packets.Packet_SpectateRequest) {
+	if senderId != c.client.Id() {
+		c.logger.Warn("Received spectate request from another client", "sender_id", senderId)
+		return
+	}
+
+	c.client.SetState(&Spectating{})
+}
+
+func (c *Connected) handleReplayRequest(senderId uint64, message *packets.Packet_ReplayRequest) {
+	if senderId != c.client.Id() {
+		c.logger.Warn("Received replay request from another client", "sender_id", senderId)
+		return
+	}
+
+	if c.client.Replays() == nil {
+		c.client.SocketSend(packets.NewDenyResponse("Replays are not available on this server"))
+		return
+	}
+
+	name := message.ReplayRequest.Name
+	reader, err := c.client.Replays().Open(name)
+	if errors.Is(err, replay.ErrNoReplays) || errors.Is(err, fs.ErrNotExist) {
+		c.client.SocketSend(packets.NewDenyResponse("No such replay"))
+		return
+	}
+	if err != nil {
+		c.logger.Warn("Error opening replay", "name", name, "error", err)
+		c.client.SocketSend(packets.NewDenyResponse("Could not open the replay"))
+		return
+	}
+
+	c.logger.Info("Watching replay", "name", name, "speed", message.ReplayRequest.Speed)
+	c.client.SocketSend(packets.NewOkResponse())
+	c.client.SetState(NewReplaying(reader, message.ReplayRequest.Speed))
+}
+
 func validateUsername(username string) error {
 	if len(username) <= 0 {
 		return errors.New("empty")
@@ -0,0 +1,90 @@
+package states
+
+import (
+	"log/slog"
+	"server/internal/server"
+	"server/internal/server/objects"
+	"server/pkg/packets"
+	"time"
+)
+
+type Dead struct {
+	client     server.ClientInterfacer
+	logger     *slog.Logger
+	player     *objects.Player
+	killerId   uint64
+	killerName string
+	finalMass  uint64
+	timeAlive  time.Duration
+}
+
+func (d *Dead) Name() string {
+	return "Dead"
+}
+
+func (d *Dead) SetClient(client server.ClientInterfacer) {
+	d.client = client
+	d.logger = client.Logger().With("state", d.Name())
+}
+
+// The player carries its best score into the next life, see InGame.ResetBestScore
+func (d *Dead) ResetBestScore() {
+	d.player.BestScore = 0
+}
+
+func (d *Dead) OnEnter() {
+	d.client.SocketSend(packets.NewDeath(d.killerId, d.killerName, d.finalMass, d.timeAlive.Seconds()))
+}
+
+func (d *Dead) HandleMessage(senderId uint64, message packets.Msg) {
+	switch message := message.(type) {
+	case *packets.Packet_RespawnRequest:
+		d.handleRespawnRequest(senderId, message)
+	case *packets.Packet_ReturnToMenu:
+		d.handleReturnToMenu(senderId, message)
+	case *packets.Packet_SpectateRequest:
+		d.handleSpectateRequest(senderId, message)
+	}
+}
+
+func (d *Dead) OnExit() {
+
+}
+
+func (d *Dead) handleRespawnRequest(senderId uint64, message *packets.Packet_RespawnRequest) {
+	if senderId != d.client.Id() {
+		d.logger.Warn("Received respawn request from another client", "sender_id", senderId)
+		return
+	}
+
+	d.logger.Info("Respawning player")
+
+	// Only carry over who the player is, everything about their last life starts over
+	d.client.SetState(&InGame{
+		player: &objects.Player{
+			Name:      d.player.Name,
+			DbId:      d.player.DbId,
+			BestScore: d.player.BestScore,
+			Color:     d.player.Color,
+			IsBot:     d.player.IsBot,
+		},
+	})
+}
+
+func (d *Dead) handleReturnToMenu(senderId uint64, message *packets.Packet_ReturnToMenu) {
+	if senderId != d.client.Id() {
+		d.logger.Warn("Received return to menu from another client", "sender_id", senderId)
+		return
+	}
+
+	d.client.SetState(&Connected{})
+}
+
+func (d *Dead) handleSpectateRequest(senderId uint64, message *packets.Packet_SpectateRequest) {
+	if senderId != d.client.Id() {
+		d.logger.Warn("Received spectate request from another client", "sender_id", senderId)
+		return
+	}
+
+	d.client.SetState(&Spectating{})
+}
@@ -3,12 +3,11 @@ package states
 import (
 	"context"
 	"fmt"
-	"log"
-	"math"
-	"math/rand/v2"
+	"log/slog"
 	"server/internal/server"
 	"server/internal/server/db"
 	"server/internal/server/objects"
+	"server/internal/server/sim"
 	"server/pkg/packets"
 	"time"
 )
@@ -16,31 +15,40 @@ import (
 type InGame struct {
 	client                 server.ClientInterfacer
 	player                 *objects.Player
-	logger                 *log.Logger
+	spawnedAt              time.Time
+	logger                 *slog.Logger
 	cancelPlayerUpdateLoop context.CancelFunc
 }
 
+func NewInGame(player *objects.Player) *InGame {
+	return &InGame{player: player}
+}
+
+// The client's own player, as opposed to the snapshot of it everyone else sees
+func (g *InGame) Player() *objects.Player {
+	return g.player
+}
+
 func (g *InGame) Name() string {
 	return "InGame"
 }
 
 func (g *InGame) SetClient(client server.ClientInterfacer) {
 	g.client = client
-	loggingPrefix := fmt.Sprintf("Client %d [%s]: ", client.Id(), g.Name())
-	g.logger = log.New(log.Writer(), loggingPrefix, log.LstdFlags)
+	g.logger = client.Logger().With("state", g.Name())
 }
 
 func (g *InGame) OnEnter() {
-	g.logger.Printf("Adding player %s to the shared collection", g.player.Name)
-	go g.client.SharedGameObjects().Players.Add(g.player, g.client.Id())
+	g.spawnedAt = g.client.Sim().Now()
+	sim.Spawn(g.player, g.spawnedAt, g.client.Sim().Rand, g.client.SharedGameObjects().Players, g.client.SharedGameObjects().Spores)
 
-	g.player.X, g.player.Y = objects.SpawnCoords(g.player.Radius, g.client.SharedGameObjects().Players, g.client.SharedGameObjects().Spores)
-	g.player.Speed = 150.0
-	g.player.Radius = 20
+	g.logger.Info("Adding player to the shared collection")
+	g.client.SharedGameObjects().Players.Add(g.player.Snapshot(), g.client.Id())
 
-	g.client.SocketSend(packets.NewPlayer(g.client.Id(), g.player))
+	g.client.SocketSendReliably(packets.NewPlayer(g.client.Id(), g.player, g.client.Sim().Now()), g.client.Id())
 
-	go g.sendInitialSpores(100, 10*time.Millisecond)
+	go sendInitialSpores(g.client, 100, 10*time.Millisecond)
+	sendPowerUps(g.client)
 }
 
 func (g *InGame) HandleMessage(senderId uint64, message packets.Msg) {
@@ -59,6 +67,10 @@ func (g *InGame) HandleMessage(senderId uint64, message packets.Msg) {
 		g.handleSpore(senderId, message)
 	case *packets.Packet_Disconnect:
 		g.handleDisconnect(senderId, message)
+	case *packets.Packet_PowerUp:
+		g.handlePowerUp(senderId, message)
+	case *packets.Packet_PowerUpConsumed:
+		g.handlePowerUpConsumed(senderId, message)
 	}
 }
 
@@ -81,7 +93,7 @@ func (g *InGame) handleChat(senderId uint64, message *packets.Packet_Chat) {
 
 func (g *InGame) handlePlayer(senderId uint64, message *packets.Packet_Player) {
 	if senderId == g.client.Id() {
-		g.logger.Println("Received player message from our own client, ignoring")
+		g.logger.Warn("Received player message from our own client, ignoring")
 		return
 	}
 
@@ -90,7 +102,11 @@ func (g *InGame) handlePlayer(senderId uint64, message *packets.Packet_Player) {
 
 func (g *InGame) handlePlayerDirection(senderId uint64, message *packets.Packet_PlayerDirection) {
 	if senderId == g.client.Id() {
-		g.player.Direction = message.PlayerDirection.Direction
+		input := message.PlayerDirection
+		if !sim.ApplyDirection(g.player, input.Direction, input.Sequence, input.ClientTime) {
+			g.logger.Debug("Ignoring stale input", "sequence", input.Sequence, "last_sequence", g.player.InputSequence)
+			return
+		}
 
 		if g.cancelPlayerUpdateLoop == nil {
 			ctx, cancel := context.WithCancel(context.Background())
@@ -110,7 +126,12 @@ func (g *InGame) updatePlayerLoop(ctx context.Context) {
 	for {
 		select {
 		case <-ticker.C:
-			g.syncPlayer(delta)
+			g.client.Post(func() {
+				// The state may have been left while this tick was waiting in the mailbox
+				if ctx.Err() == nil {
+					g.syncPlayer(delta)
+				}
+			})
 		case <-ctx.Done():
 			return
 		}
@@ -118,32 +139,31 @@ func (g *InGame) updatePlayerLoop(ctx context.Context) {
 }
 
 func (g *InGame) syncPlayer(delta float64) {
-	newX := g.player.X + g.player.Speed*math.Cos(g.player.Direction)*delta
-	newY := g.player.Y + g.player.Speed*math.Sin(g.player.Direction)*delta
-
-	g.player.X = newX
-	g.player.Y = newY
-
-	probability := g.player.Radius / float64(server.MaxSpores*5)
-	if rand.Float64() < probability && g.player.Radius > 10 {
-		spore := &objects.Spore{
-			X:         g.player.X,
-			Y:         g.player.Y,
-			Radius:    min(5+g.player.Radius/50, 15),
-			DroppedBy: g.player,
-			DroppedAt: time.Now(),
-		}
+	now := g.client.Sim().Now()
+	result := sim.Step(g.player, delta, now, g.client.Sim().Rand, g.client.Settings().MaxSpores)
+
+	if spore := result.Dropped; spore != nil {
 		sporeId := g.client.SharedGameObjects().Spores.Add(spore)
 		g.client.Broadcast(packets.NewSpore(sporeId, spore))
-		go g.client.SocketSend(packets.NewSpore(sporeId, spore))
+		g.client.SocketSend(packets.NewSpore(sporeId, spore))
+	}
 
-		g.player.Radius = g.nextRadius(-radToMass(spore.Radius))
+	if g.player.HasEffect(objects.PowerUpMagnet, now) {
+		g.attractSpores(now)
 	}
 
-	updatePacket := packets.NewPlayer(g.client.Id(), g.player)
+	g.publishPlayer()
+
+	updatePacket := packets.NewPlayer(g.client.Id(), g.player, now)
 	g.client.Broadcast(updatePacket)
 
-	go g.client.SocketSend(updatePacket)
+	g.client.SocketSend(updatePacket)
+}
+
+// Lets everyone else see the latest version of our player. If we've been consumed in the
+// meantime the player is gone from the collection, and stays gone.
+func (g *InGame) publishPlayer() {
+	g.client.SharedGameObjects().Players.Replace(g.client.Id(), g.player.Snapshot())
 }
 
 func (g *InGame) handleSporeConsumed(senderId uint64, message *packets.Packet_SporeConsumed) {
@@ -152,36 +172,36 @@ func (g *InGame) handleSporeConsumed(senderId uint64, message *packets.Packet_Sp
 		return
 	}
 
-	errorsMsg := "Could not verify spore consumption: "
+	errorsMsg := "Could not verify spore consumption"
 
 	sporeId := message.SporeConsumed.SporeId
 	spore, err := g.getSpore(sporeId)
 
 	if err != nil {
-		g.logger.Println(errorsMsg + err.Error())
+		g.logger.Warn(errorsMsg, "error", err)
 		return
 	}
 
-	err = g.validatePlayerCloseToObject(spore.X, spore.Y, spore.Radius, 10)
+	now := g.client.Sim().Now()
+	err = sim.CheckReachSince(g.player, g.claimedSince(now), now, spore.X, spore.Y, spore.Radius, sim.ReachBuffer)
 	if err != nil {
-		g.logger.Println(errorsMsg + err.Error())
+		g.rejectClaim(errorsMsg, server.OffenceOutOfReach, err)
 		return
 	}
 
-	err = g.validatePlayerDropCooldown(spore, 10)
+	err = sim.CheckDropCooldown(g.player, spore, sim.DropClearance, now)
 	if err != nil {
-		g.logger.Println(errorsMsg + err.Error())
+		g.rejectClaim(errorsMsg, server.OffenceDropCooldown, err)
 		return
 	}
 
-	sporeMass := radToMass(spore.Radius)
-	g.player.Radius = g.nextRadius(sporeMass)
+	g.player.Radius = sim.Grow(g.player.Radius, sim.RadToMass(spore.Radius))
 
-	go g.client.SharedGameObjects().Spores.Remove(sporeId)
+	g.client.SharedGameObjects().Spores.Remove(sporeId)
 
 	g.client.Broadcast(message)
 
-	go g.syncPlayerBestScore()
+	g.syncPlayerBestScore()
 }
 
 func (g *InGame) handlePlayerConsumed(senderId uint64, message *packets.Packet_PlayerConsumed) {
@@ -189,50 +209,60 @@ func (g *InGame) handlePlayerConsumed(senderId uint64, message *packets.Packet_P
 		g.client.SocketSendAs(message, senderId)
 
 		if message.PlayerConsumed.PlayerId == g.client.Id() {
-			g.logger.Println("Player was consumed, respawing")
-			g.client.SetState(&InGame{
-				player: &objects.Player{
-					Name: g.player.Name,
-				},
-			})
+			g.logger.Info("Player was consumed", "consumed_by", senderId)
+			g.client.SetState(g.newDead(senderId))
 		}
 
 		return
 	}
 
-	errorsMsg := "Could not verify player consumption: "
+	errorsMsg := "Could not verify player consumption"
 
 	otherId := message.PlayerConsumed.PlayerId
 	other, err := g.getOtherPlayer(otherId)
 	if err != nil {
-		g.logger.Println(errorsMsg + err.Error())
+		g.logger.Warn(errorsMsg, "error", err)
 		return
 	}
 
-	ourMass := radToMass(g.player.Radius)
-	otherMass := radToMass(other.Radius)
-
-	if ourMass <= otherMass*1.5 {
-		g.logger.Printf(errorsMsg+"player not massive enough to consume the other player (our radius: %f, other radius: %f)", g.player.Radius, other.Radius)
+	now := g.client.Sim().Now()
+	err = sim.CheckConsume(g.player, other, now)
+	if err != nil {
+		g.rejectClaim(errorsMsg, server.OffenceInvalidClaim, fmt.Errorf("consuming player %d: %w", otherId, err))
 		return
 	}
 
 	// Finally, check if the player is close enough to the other to be consumed
-	err = g.validatePlayerCloseToObject(other.X, other.Y, other.Radius, 10)
+	err = sim.CheckReachPlayerSince(g.player, other, g.claimedSince(now), now, sim.ReachBuffer)
 	if err != nil {
-		g.logger.Println(errorsMsg + err.Error())
+		g.rejectClaim(errorsMsg, server.OffenceOutOfReach, err)
 		return
 	}
 
 	// If we made it this far, the player consumption is valid, so grow the player, remove the consumed other, and broadcast the event
-	g.player.Radius = g.nextRadius(otherMass)
+	g.player.Radius = sim.Grow(g.player.Radius, sim.RadToMass(other.Radius))
 
-	go g.client.SharedGameObjects().Players.Remove(otherId)
+	g.client.SharedGameObjects().Players.Remove(otherId)
 
 	g.client.Broadcast(message)
 
-	go g.syncPlayerBestScore()
+	g.syncPlayerBestScore()
+
+}
 
+func (g *InGame) newDead(killerId uint64) *Dead {
+	killerName := "unknown"
+	if killer, err := g.getOtherPlayer(killerId); err == nil {
+		killerName = killer.Name
+	}
+
+	return &Dead{
+		player:     g.player,
+		killerId:   killerId,
+		killerName: killerName,
+		finalMass:  uint64(sim.Score(g.player.Radius)),
+		timeAlive:  g.client.Sim().Now().Sub(g.spawnedAt),
+	}
 }
 
 func (g *InGame) handleDisconnect(senderId uint64, message *packets.Packet_Disconnect) {
@@ -242,30 +272,87 @@ func (g *InGame) handleDisconnect(senderId uint64, message *packets.Packet_Disco
 		return
 	}
 
-	go g.client.SocketSendAs(message, senderId)
+	g.client.SocketSendAs(message, senderId)
 }
 
 func (g *InGame) handleSpore(senderId uint64, message *packets.Packet_Spore) {
 	g.client.SocketSendAs(message, senderId)
 }
 
-func (g *InGame) sendInitialSpores(batchSize int, delay time.Duration) {
-	sporesBatch := make(map[uint64]*objects.Spore, batchSize)
+func (g *InGame) handlePowerUp(senderId uint64, message *packets.Packet_PowerUp) {
+	g.client.SocketSendAs(message, senderId)
+}
 
+func (g *InGame) handlePowerUpConsumed(senderId uint64, message *packets.Packet_PowerUpConsumed) {
+	if senderId != g.client.Id() {
+		g.client.SocketSendAs(message, senderId)
+		return
+	}
+
+	errorsMsg := "Could not verify power-up consumption"
+
+	powerUpId := message.PowerUpConsumed.PowerUpId
+	powerUp, exists := g.client.SharedGameObjects().PowerUps.Get(powerUpId)
+	if !exists {
+		g.logger.Warn(errorsMsg, "error", "power-up does not exist", "power_up_id", powerUpId)
+		return
+	}
+
+	now := g.client.Sim().Now()
+	err := sim.CheckReachSince(g.player, g.claimedSince(now), now, powerUp.X, powerUp.Y, powerUp.Radius, sim.ReachBuffer)
+	if err != nil {
+		g.rejectClaim(errorsMsg, server.OffenceOutOfReach, err)
+		return
+	}
+
+	g.client.SharedGameObjects().PowerUps.Remove(powerUpId)
+	g.player.AddEffect(powerUp.Kind, now)
+	g.logger.Info("Picked up power-up", "power_up_id", powerUpId, "kind", powerUp.Kind, "active_for", g.player.EffectRemaining(powerUp.Kind, now))
+
+	g.client.Broadcast(message)
+}
+
+// Consumes every spore within the magnet's reach on the player's behalf
+func (g *InGame) attractSpores(now time.Time) {
 	g.client.SharedGameObjects().Spores.ForEach(func(sporeId uint64, spore *objects.Spore) {
+		if !sim.Attracts(g.player, spore, now) {
+			return
+		}
+
+		g.client.SharedGameObjects().Spores.Remove(sporeId)
+		g.player.Radius = sim.Grow(g.player.Radius, sim.RadToMass(spore.Radius))
+
+		message := &packets.Packet_SporeConsumed{
+			SporeConsumed: &packets.SporeConsumedMessage{SporeId: sporeId},
+		}
+		g.client.Broadcast(message)
+		g.client.SocketSend(message)
+	})
+}
+
+func sendInitialSpores(client server.ClientInterfacer, batchSize int, delay time.Duration) {
+	sporesBatch := make(map[uint64]*objects.Spore, batchSize)
+
+	client.SharedGameObjects().Spores.ForEach(func(sporeId uint64, spore *objects.Spore) {
 		sporesBatch[sporeId] = spore
 		if len(sporesBatch) >= batchSize {
-			g.client.SocketSend(packets.NewSporeBatch(sporesBatch))
+			client.SocketSend(packets.NewSporeBatch(sporesBatch))
 			sporesBatch = make(map[uint64]*objects.Spore, batchSize)
 			time.Sleep(delay)
 		}
 	})
 
 	if len(sporesBatch) > 0 {
-		g.client.SocketSend(packets.NewSporeBatch(sporesBatch))
+		client.SocketSend(packets.NewSporeBatch(sporesBatch))
 	}
 }
 
+func sendPowerUps(client server.ClientInterfacer) {
+	client.SharedGameObjects().PowerUps.ForEach(func(powerUpId uint64, powerUp *objects.PowerUp) {
+		client.SocketSend(packets.NewPowerUp(powerUpId, powerUp))
+	})
+}
+
 func (g *InGame) getSpore(sporeId uint64) (*objects.Spore, error) {
 	spore, exists := g.client.SharedGameObjects().Spores.Get(sporeId)
 
@@ -286,46 +373,32 @@ func (g *InGame) getOtherPlayer(playerId uint64) (*objects.Player, error) {
 	return player, nil
 }
 
-func (g *InGame) validatePlayerCloseToObject(objX, objY, objRadius, buffer float64) error {
-	realDX := g.player.X - objX
-	realDY := g.player.Y - objY
-	realDistSq := realDX*realDX + realDY*realDY
-
-	thresholdDist := g.player.Radius + buffer + objRadius
-	thresholdDistSq := thresholdDist * thresholdDist
-
-	if realDistSq > thresholdDistSq {
-		return fmt.Errorf("player is too far from the object (distSq %f, thresholdSq %f)", realDistSq, thresholdDistSq)
-	}
-
-	return nil
+// Throws away a claim from our client that doesn't add up, holding it against the player.
+// Claims about objects that are already gone aren't held against anyone, since with lag
+// that's bound to happen.
+func (g *InGame) rejectClaim(errorsMsg string, offence server.Offence, err error) {
+	g.logger.Warn(errorsMsg, "offence", offence, "error", err)
+	g.client.Suspect(offence, err)
 }
 
-func (g *InGame) validatePlayerDropCooldown(spore *objects.Spore, buffer float64) error {
-	minAcceptableDistance := spore.Radius + g.player.Radius + buffer
-	minAcceptableTime := time.Duration(minAcceptableDistance/g.player.Speed*1000) * time.Millisecond
-	if spore.DroppedBy == g.player && time.Since(spore.DroppedAt) < minAcceptableTime {
-		return fmt.Errorf("player dropped the spore too recently (time %v, min acceptable time: %v)", time.Since(spore.DroppedAt), minAcceptableTime)
-	}
-	return nil
-}
-
-func radToMass(radius float64) float64 {
-	return math.Pi * radius * radius
-}
-
-func massToRad(mass float64) float64 {
-	return math.Sqrt(mass / math.Pi)
+// How far back the client's view of the game may have been when it made a claim that's just
+// arrived, going by its round trip time
+func (g *InGame) claimedSince(now time.Time) time.Time {
+	return now.Add(-sim.RewindWindow(g.client.RTT()))
 }
 
-func (g *InGame) nextRadius(massDiff float64) float64 {
-	oldMass := radToMass(g.player.Radius)
-	newMass := oldMass + massDiff
-	return massToRad(newMass)
+// Forgets the best score the player started with, for when it's been reset in the database
+// behind the player's back, so the old one isn't saved again
+func (g *InGame) ResetBestScore() {
+	g.player.BestScore = 0
 }
 
 func (g *InGame) syncPlayerBestScore() {
-	currentScore := int64(math.Round(radToMass(g.player.Radius)))
+	if g.player.IsBot {
+		return
+	}
+
+	currentScore := sim.Score(g.player.Radius)
 	if currentScore > g.player.BestScore {
 		g.player.BestScore = currentScore
 		err := g.client.DbTx().Queries.UpdatePlayerBestScore(g.client.DbTx().Ctx, db.UpdatePlayerBestScoreParams{
@@ -333,7 +406,7 @@ func (g *InGame) syncPlayerBestScore() {
 			BestScore: g.player.BestScore,
 		})
 		if err != nil {
-			g.logger.Printf("Error updating player best score: %v", err)
+			g.logger.Error("Error updating player best score", "error", err)
 		}
 	}
 }
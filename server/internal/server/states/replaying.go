@@ -0,0 +1,207 @@
+package states
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"server/internal/server"
+	"server/internal/server/replay"
+	"server/pkg/packets"
+	"time"
+)
+
+// Plays a recorded game to the client as though it were spectating it live, following the
+// biggest player unless told to follow someone else. Nothing live reaches the client meanwhile.
+type Replaying struct {
+	client server.ClientInterfacer
+	logger *slog.Logger
+	reader *replay.Reader
+	// How many times faster than real time to play the replay
+	speed float64
+
+	cancelPlayback context.CancelFunc
+	// The radius of every player in the replay right now, to know who's leading
+	players      map[uint64]float64
+	targetId     uint64
+	followLeader bool
+}
+
+// Takes over the reader, which is closed once the replay is over or the client leaves
+func NewReplaying(reader *replay.Reader, speed float64) *Replaying {
+	if speed <= 0 {
+		speed = 1
+	}
+	return &Replaying{
+		reader:  reader,
+		speed:   speed,
+		players: make(map[uint64]float64),
+	}
+}
+
+func (r *Replaying) Name() string {
+	return "Replaying"
+}
+
+func (r *Replaying) SetClient(client server.ClientInterfacer) {
+	r.client = client
+	r.logger = client.Logger().With("state", r.Name())
+}
+
+func (r *Replaying) OnEnter() {
+	r.followLeader = true
+
+	ctx, cancel := context.WithCancel(context.Background())
+	r.cancelPlayback = cancel
+	go r.playbackLoop(ctx)
+}
+
+func (r *Replaying) HandleMessage(senderId uint64, message packets.Msg) {
+	if senderId != r.client.Id() {
+		return
+	}
+
+	switch message := message.(type) {
+	case *packets.Packet_SpectateTarget:
+		r.handleSpectateTarget(message)
+	case *packets.Packet_FinishedSpectating:
+		r.client.SetState(&Connected{})
+	}
+}
+
+func (r *Replaying) OnExit() {
+	r.cancelPlayback()
+}
+
+// Reads the replay ahead of the event loop, handing each packet to it when it's due
+func (r *Replaying) playbackLoop(ctx context.Context) {
+	defer r.reader.Close()
+
+	// Paced by the wall clock rather than the game's, since it's someone watching in real time
+	// that the frames are played to, however the game that was recorded kept time
+	start := time.Now()
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		frame, err := r.reader.Next()
+		if err != nil {
+			r.client.Post(func() {
+				if ctx.Err() == nil {
+					r.finish(err)
+				}
+			})
+			return
+		}
+
+		timer.Reset(time.Until(start.Add(time.Duration(float64(frame.Offset) / r.speed))))
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			return
+		}
+
+		r.client.Post(func() {
+			// The state may have been left while this frame was waiting in the mailbox
+			if ctx.Err() == nil {
+				r.play(frame.Packet)
+			}
+		})
+	}
+}
+
+// Recorded players are shown under ids this far along, so none of them can be mistaken for
+// the viewer, whose id is a live one
+const replayIdOffset uint64 = 1 << 32
+
+// The id a recorded player is shown under. Zero stays zero, being no one in particular.
+func replayId(recordedId uint64) uint64 {
+	if recordedId == 0 {
+		return 0
+	}
+	return recordedId + replayIdOffset
+}
+
+func (r *Replaying) play(packet *packets.Packet) {
+	senderId := replayId(packet.SenderId)
+
+	switch message := packet.Msg.(type) {
+	case *packets.Packet_Player:
+		message.Player.Id = replayId(message.Player.Id)
+		r.players[senderId] = message.Player.Radius
+		if r.followLeader && message.Player.Radius > r.players[r.targetId] {
+			r.setTarget(senderId)
+		}
+	case *packets.Packet_PlayerConsumed:
+		message.PlayerConsumed.PlayerId = replayId(message.PlayerConsumed.PlayerId)
+		delete(r.players, message.PlayerConsumed.PlayerId)
+		if message.PlayerConsumed.PlayerId == r.targetId {
+			r.retarget(senderId)
+		}
+	case *packets.Packet_Disconnect:
+		delete(r.players, senderId)
+		if senderId == r.targetId {
+			r.retarget(0)
+		}
+	}
+
+	r.client.SocketSendAs(packet.Msg, senderId)
+}
+
+func (r *Replaying) finish(err error) {
+	if !errors.Is(err, io.EOF) {
+		// Most likely recorded up until the server stopped, in which case it's only missing the very end
+		r.logger.Warn("Replay ended early", "error", err)
+	}
+	r.logger.Info("Replay finished")
+	r.client.SocketSendAs(packets.NewChat("The replay has ended"), 0)
+}
+
+func (r *Replaying) handleSpectateTarget(message *packets.Packet_SpectateTarget) {
+	targetId := message.SpectateTarget.PlayerId
+	if targetId == 0 {
+		r.followLeader = true
+		r.setTarget(r.findLeader())
+		return
+	}
+
+	if _, exists := r.players[targetId]; !exists {
+		r.client.SocketSend(packets.NewDenyResponse("That player is not in game"))
+		return
+	}
+
+	r.followLeader = false
+	r.setTarget(targetId)
+}
+
+// Moves the camera off a target who's gone, onto the leader, or whoever ate the target if
+// the client chose who to follow
+func (r *Replaying) retarget(consumedBy uint64) {
+	if r.followLeader || consumedBy == 0 {
+		r.followLeader = true
+		r.setTarget(r.findLeader())
+		return
+	}
+	r.setTarget(consumedBy)
+}
+
+func (r *Replaying) setTarget(targetId uint64) {
+	if targetId == r.targetId {
+		return
+	}
+	r.targetId = targetId
+	r.client.SocketSend(packets.NewSpectateTarget(targetId))
+}
+
+func (r *Replaying) findLeader() uint64 {
+	var leaderId uint64
+	leaderRadius := 0.0
+
+	for playerId, radius := range r.players {
+		if radius > leaderRadius {
+			leaderId, leaderRadius = playerId, radius
+		}
+	}
+
+	return leaderId
+}
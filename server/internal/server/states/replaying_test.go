@@ -0,0 +1,74 @@
+package states_test
+
+import (
+	"path/filepath"
+	"server/internal/server/replay"
+	"server/internal/server/servertest"
+	"server/pkg/packets"
+	"testing"
+	"time"
+)
+
+// Gives the server a replay library holding one replay of the packets, all recorded at once
+func serveReplay(t *testing.T, srv *servertest.Server, recorded ...*packets.Packet) {
+	t.Helper()
+
+	dir := t.TempDir()
+	library, err := replay.NewLibrary(dir)
+	if err != nil {
+		t.Fatalf("Error creating library: %v", err)
+	}
+	srv.Hub.Replays = library
+
+	start := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	writer, err := replay.Create(filepath.Join(dir, "20240501-120000.replay.gz"), start)
+	if err != nil {
+		t.Fatalf("Error creating replay: %v", err)
+	}
+	for _, packet := range recorded {
+		if err := writer.Write(start, packet); err != nil {
+			t.Fatalf("Error writing replay: %v", err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Error closing replay: %v", err)
+	}
+}
+
+func TestReplayedPlayersAreNeverTheViewer(t *testing.T) {
+	srv := servertest.NewServer(t)
+	viewer := srv.Connect(t)
+
+	// Recorded on a server where the viewer's id belonged to someone else
+	serveReplay(t, srv,
+		&packets.Packet{SenderId: viewer.Id(), Msg: &packets.Packet_Player{Player: &packets.PlayerMessage{Id: viewer.Id(), Name: "someone", Radius: 50}}},
+		&packets.Packet{SenderId: viewer.Id() + 1, Msg: &packets.Packet_PlayerConsumed{PlayerConsumed: &packets.PlayerConsumedMessage{PlayerId: viewer.Id()}}},
+	)
+
+	viewer.Inject(&packets.Packet_ReplayRequest{ReplayRequest: &packets.ReplayRequestMessage{}})
+
+	// The last thing recorded
+	consumed := servertest.Expect[*packets.Packet_PlayerConsumed](t, viewer).PlayerConsumed
+	if consumed.PlayerId == viewer.Id() {
+		t.Errorf("Recorded player was consumed under the viewer's id, %d", consumed.PlayerId)
+	}
+
+	var followed []uint64
+	for _, packet := range viewer.Sent() {
+		switch message := packet.Msg.(type) {
+		case *packets.Packet_Player:
+			if packet.SenderId == viewer.Id() || message.Player.Id == viewer.Id() {
+				t.Errorf("Recorded player was shown as the viewer, %d", viewer.Id())
+			}
+			if message.Player.Id != consumed.PlayerId {
+				t.Errorf("Recorded player is shown as %d, then consumed as %d", message.Player.Id, consumed.PlayerId)
+			}
+		case *packets.Packet_SpectateTarget:
+			followed = append(followed, message.SpectateTarget.PlayerId)
+		}
+	}
+	// The recorded player, then no one once it's gone
+	if len(followed) != 2 || followed[0] != consumed.PlayerId || followed[1] != 0 {
+		t.Errorf("Followed %v, want %d then 0", followed, consumed.PlayerId)
+	}
+}
@@ -0,0 +1,175 @@
+package states_test
+
+import (
+	"reflect"
+	"server/internal/server/clients"
+	"server/internal/server/servertest"
+	"server/pkg/packets"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// Some other client, as far as any state is concerned
+const foreignId uint64 = 4242
+
+// One of every message, as a peer might pass it on. The player consumed is nobody in particular,
+// since a peer consuming our own player is meant to change our state.
+var everyMessage = []packets.Msg{
+	packets.NewChat("hello"),
+	&packets.Packet_Player{Player: &packets.PlayerMessage{Id: foreignId, Name: "someone"}},
+	&packets.Packet_PlayerDirection{PlayerDirection: &packets.PlayerDirectionMessage{Direction: 1}},
+	&packets.Packet_Spore{Spore: &packets.SporeMessage{Id: 1}},
+	&packets.Packet_SporeConsumed{SporeConsumed: &packets.SporeConsumedMessage{SporeId: 1}},
+	&packets.Packet_PlayerConsumed{PlayerConsumed: &packets.PlayerConsumedMessage{PlayerId: foreignId + 1}},
+	&packets.Packet_PowerUp{PowerUp: &packets.PowerUpMessage{Id: 1}},
+	&packets.Packet_PowerUpConsumed{PowerUpConsumed: &packets.PowerUpConsumedMessage{PowerUpId: 1}},
+	&packets.Packet_LoginRequest{LoginRequest: &packets.LoginRequestMessage{Username: "someone", Password: "secret"}},
+	&packets.Packet_RegisterRequest{RegisterRequest: &packets.RegisterRequestMessage{Username: "someone", Password: "secret"}},
+	&packets.Packet_HiscoreBoardRequest{HiscoreBoardRequest: &packets.HiscoreBoardRequestMessage{}},
+	&packets.Packet_FinishedBrowsingHiscores{FinishedBrowsingHiscores: &packets.FinishedBrowsingHiscoresMessage{}},
+	&packets.Packet_SearchHiscore{SearchHiscore: &packets.SearchHiscoreMessage{Name: "someone"}},
+	&packets.Packet_SpectateRequest{SpectateRequest: &packets.SpectateRequestMessage{}},
+	&packets.Packet_SpectateTarget{SpectateTarget: &packets.SpectateTargetMessage{PlayerId: foreignId}},
+	&packets.Packet_FinishedSpectating{FinishedSpectating: &packets.FinishedSpectatingMessage{}},
+	&packets.Packet_RespawnRequest{RespawnRequest: &packets.RespawnRequestMessage{}},
+	&packets.Packet_ReturnToMenu{ReturnToMenu: &packets.ReturnToMenuMessage{}},
+	&packets.Packet_ReplayRequest{ReplayRequest: &packets.ReplayRequestMessage{Name: "latest"}},
+	packets.NewDisconnect("left"),
+}
+
+// What happens in a game, which clients in game or spectating pass on from their peers
+var gameMessages = []packets.Msg{
+	&packets.Packet_Chat{},
+	&packets.Packet_Player{},
+	&packets.Packet_PlayerDirection{},
+	&packets.Packet_Spore{},
+	&packets.Packet_SporeConsumed{},
+	&packets.Packet_PlayerConsumed{},
+	&packets.Packet_PowerUp{},
+	&packets.Packet_PowerUpConsumed{},
+	&packets.Packet_Disconnect{},
+}
+
+// Waits for everything posted to the client so far to be handled, returning the state it
+// ends up in
+func stateAfterMailbox(client *clients.LoopbackClient) string {
+	stateName := make(chan string, 1)
+	client.Post(func() {
+		if state := client.State(); state != nil {
+			stateName <- state.Name()
+		} else {
+			stateName <- "None"
+		}
+	})
+	return <-stateName
+}
+
+func TestForeignSenders(t *testing.T) {
+	tests := []struct {
+		state  string
+		enter  func(t *testing.T, srv *servertest.Server) *clients.LoopbackClient
+		passOn []packets.Msg
+	}{
+		{
+			state: "Connected",
+			enter: func(t *testing.T, srv *servertest.Server) *clients.LoopbackClient {
+				return srv.Connect(t)
+			},
+		},
+		{
+			state: "BrowsingHiscores",
+			enter: func(t *testing.T, srv *servertest.Server) *clients.LoopbackClient {
+				client := srv.Connect(t)
+				client.Inject(&packets.Packet_HiscoreBoardRequest{HiscoreBoardRequest: &packets.HiscoreBoardRequestMessage{}})
+				return client
+			},
+		},
+		{
+			state: "InGame",
+			enter: func(t *testing.T, srv *servertest.Server) *clients.LoopbackClient {
+				return srv.Join(t, "player", "secret")
+			},
+			passOn: gameMessages,
+		},
+		{
+			state: "Dead",
+			enter: func(t *testing.T, srv *servertest.Server) *clients.LoopbackClient {
+				client := srv.Join(t, "player", "secret")
+				client.ProcessMessage(foreignId, &packets.Packet_PlayerConsumed{
+					PlayerConsumed: &packets.PlayerConsumedMessage{PlayerId: client.Id()},
+				})
+				servertest.Expect[*packets.Packet_Death](t, client)
+				return client
+			},
+		},
+		{
+			state: "Spectating",
+			enter: func(t *testing.T, srv *servertest.Server) *clients.LoopbackClient {
+				client := srv.Connect(t)
+				client.Inject(&packets.Packet_SpectateRequest{SpectateRequest: &packets.SpectateRequestMessage{}})
+				return client
+			},
+			passOn: gameMessages,
+		},
+		{
+			state: "Replaying",
+			enter: func(t *testing.T, srv *servertest.Server) *clients.LoopbackClient {
+				client := srv.Connect(t)
+				serveReplay(t, srv, &packets.Packet{SenderId: 1, Msg: &packets.Packet_Player{Player: &packets.PlayerMessage{Id: 1, Name: "someone"}}})
+				client.Inject(&packets.Packet_ReplayRequest{ReplayRequest: &packets.ReplayRequestMessage{}})
+				servertest.Expect[*packets.Packet_OkResponse](t, client)
+				return client
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.state, func(t *testing.T) {
+			srv := servertest.NewServer(t)
+			client := test.enter(t, srv)
+
+			if state := stateAfterMailbox(client); state != test.state {
+				t.Fatalf("Client is in state %s, want %s", state, test.state)
+			}
+
+			for _, message := range everyMessage {
+				sentBefore := len(client.Sent())
+
+				client.ProcessMessage(foreignId, message)
+				if state := stateAfterMailbox(client); state != test.state {
+					t.Fatalf("%T from another client moved the client to state %s", message, state)
+				}
+
+				// Whatever the client sends in its own name, like a new spectate target, is
+				// its own business. All that matters is what gets through from the peer.
+				var passedOn []*packets.Packet
+				for _, packet := range client.Sent()[sentBefore:] {
+					if packet.SenderId == foreignId {
+						passedOn = append(passedOn, packet)
+					}
+				}
+
+				if !isAnyOf(message, test.passOn) {
+					if len(passedOn) > 0 {
+						t.Errorf("%T from another client got through to the socket", message)
+					}
+					continue
+				}
+
+				if len(passedOn) != 1 || !proto.Equal(passedOn[0], &packets.Packet{SenderId: foreignId, Msg: message}) {
+					t.Errorf("%T from another client wasn't passed on as it was, got %v", message, passedOn)
+				}
+			}
+		})
+	}
+}
+
+func isAnyOf(message packets.Msg, kinds []packets.Msg) bool {
+	for _, kind := range kinds {
+		if reflect.TypeOf(message) == reflect.TypeOf(kind) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,164 @@
+package states
+
+import (
+	"log/slog"
+	"server/internal/server"
+	"server/internal/server/objects"
+	"server/pkg/packets"
+	"time"
+)
+
+type Spectating struct {
+	client       server.ClientInterfacer
+	logger       *slog.Logger
+	targetId     uint64
+	followLeader bool
+}
+
+func (s *Spectating) Name() string {
+	return "Spectating"
+}
+
+func (s *Spectating) SetClient(client server.ClientInterfacer) {
+	s.client = client
+	s.logger = client.Logger().With("state", s.Name())
+}
+
+func (s *Spectating) OnEnter() {
+	s.client.SharedGameObjects().Players.ForEach(func(playerId uint64, player *objects.Player) {
+		s.client.SocketSendReliably(packets.NewPlayer(playerId, player, s.client.Sim().Now()), playerId)
+	})
+
+	go sendInitialSpores(s.client, 100, 10*time.Millisecond)
+	sendPowerUps(s.client)
+
+	s.followLeader = true
+	s.setTarget(s.findLeader())
+}
+
+func (s *Spectating) HandleMessage(senderId uint64, message packets.Msg) {
+	switch message := message.(type) {
+	case *packets.Packet_SpectateTarget:
+		s.handleSpectateTarget(senderId, message)
+	case *packets.Packet_FinishedSpectating:
+		s.handleFinishedSpectating(senderId, message)
+	case *packets.Packet_Player:
+		s.handlePlayer(senderId, message)
+	case *packets.Packet_PlayerConsumed:
+		s.handlePlayerConsumed(senderId, message)
+	case *packets.Packet_Disconnect:
+		s.handleDisconnect(senderId, message)
+	case *packets.Packet_PlayerDirection, *packets.Packet_Chat, *packets.Packet_Spore, *packets.Packet_SporeConsumed,
+		*packets.Packet_PowerUp, *packets.Packet_PowerUpConsumed:
+		if senderId != s.client.Id() {
+			s.client.SocketSendAs(message, senderId)
+		}
+	}
+}
+
+func (s *Spectating) OnExit() {
+
+}
+
+func (s *Spectating) handleSpectateTarget(senderId uint64, message *packets.Packet_SpectateTarget) {
+	if senderId != s.client.Id() {
+		s.logger.Warn("Received spectate target from another client", "sender_id", senderId)
+		return
+	}
+
+	targetId := message.SpectateTarget.PlayerId
+	if targetId == 0 {
+		s.followLeader = true
+		s.setTarget(s.findLeader())
+		return
+	}
+
+	if _, exists := s.client.SharedGameObjects().Players.Get(targetId); !exists || targetId == s.client.Id() {
+		s.client.SocketSend(packets.NewDenyResponse("That player is not in game"))
+		return
+	}
+
+	s.followLeader = false
+	s.setTarget(targetId)
+}
+
+func (s *Spectating) handleFinishedSpectating(senderId uint64, message *packets.Packet_FinishedSpectating) {
+	if senderId != s.client.Id() {
+		return
+	}
+	s.client.SetState(&Connected{})
+}
+
+func (s *Spectating) handlePlayer(senderId uint64, message *packets.Packet_Player) {
+	if senderId == s.client.Id() {
+		return
+	}
+
+	s.client.SocketSendAs(message, senderId)
+
+	if s.followLeader && senderId != s.targetId {
+		if s.isBiggerThanTarget(message.Player.Radius) {
+			s.setTarget(senderId)
+		}
+	}
+}
+
+func (s *Spectating) handlePlayerConsumed(senderId uint64, message *packets.Packet_PlayerConsumed) {
+	// Spectators aren't in the game, so they can't have eaten anyone
+	if senderId == s.client.Id() {
+		return
+	}
+	s.client.SocketSendAs(message, senderId)
+
+	if message.PlayerConsumed.PlayerId == s.targetId {
+		s.logger.Info("Target was consumed", "target_id", s.targetId, "consumed_by", senderId)
+		if s.followLeader {
+			s.setTarget(s.findLeader())
+		} else {
+			// Keep the camera on whoever ate our target
+			s.setTarget(senderId)
+		}
+	}
+}
+
+func (s *Spectating) handleDisconnect(senderId uint64, message *packets.Packet_Disconnect) {
+	if senderId == s.client.Id() {
+		return
+	}
+	s.client.SocketSendAs(message, senderId)
+
+	if senderId == s.targetId {
+		s.followLeader = true
+		s.setTarget(s.findLeader())
+	}
+}
+
+func (s *Spectating) setTarget(targetId uint64) {
+	if targetId == s.targetId {
+		return
+	}
+	s.targetId = targetId
+	s.client.SocketSend(packets.NewSpectateTarget(targetId))
+}
+
+func (s *Spectating) isBiggerThanTarget(radius float64) bool {
+	target, exists := s.client.SharedGameObjects().Players.Get(s.targetId)
+	return !exists || radius > target.Radius
+}
+
+func (s *Spectating) findLeader() uint64 {
+	var leaderId uint64
+	leaderRadius := 0.0
+
+	s.client.SharedGameObjects().Players.ForEach(func(playerId uint64, player *objects.Player) {
+		// Only there if the spectator's player hasn't been taken out of the game yet
+		if playerId == s.client.Id() {
+			return
+		}
+		if player.Radius > leaderRadius {
+			leaderId, leaderRadius = playerId, player.Radius
+		}
+	})
+
+	return leaderId
+}
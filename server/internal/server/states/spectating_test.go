@@ -0,0 +1,45 @@
+package states_test
+
+import (
+	"server/internal/server/objects"
+	"server/internal/server/servertest"
+	"server/pkg/packets"
+	"testing"
+)
+
+func TestSpectatorIgnoresItself(t *testing.T) {
+	srv := servertest.NewServer(t)
+	alice := srv.Join(t, "alice", "secret")
+	// Sent once alice's player is in the game
+	servertest.Expect[*packets.Packet_Player](t, alice)
+	spectator := srv.Connect(t)
+
+	// Left behind under the spectator's id, as though its player hadn't been taken out of the
+	// game yet, and bigger than anyone
+	srv.Hub.SharedGameObjects.Players.Add(&objects.Player{Name: "ghost", Radius: 1000}, spectator.Id())
+
+	spectator.Inject(&packets.Packet_SpectateRequest{SpectateRequest: &packets.SpectateRequestMessage{}})
+	target := servertest.Expect[*packets.Packet_SpectateTarget](t, spectator)
+	if target.SpectateTarget.PlayerId != alice.Id() {
+		t.Fatalf("Spectating %d, want alice (%d)", target.SpectateTarget.PlayerId, alice.Id())
+	}
+
+	spectator.Inject(&packets.Packet_SpectateTarget{SpectateTarget: &packets.SpectateTargetMessage{PlayerId: spectator.Id()}})
+	servertest.Expect[*packets.Packet_DenyResponse](t, spectator)
+
+	sentBefore := len(spectator.Sent())
+	spectator.Inject(&packets.Packet_PlayerConsumed{PlayerConsumed: &packets.PlayerConsumedMessage{PlayerId: alice.Id()}})
+	spectator.Inject(packets.NewDisconnect("left"))
+	if state := stateAfterMailbox(spectator); state != "Spectating" {
+		t.Fatalf("Spectator is in state %s, want Spectating", state)
+	}
+
+	for _, packet := range spectator.Sent()[sentBefore:] {
+		switch packet.Msg.(type) {
+		case *packets.Packet_SpectateTarget:
+			t.Errorf("Spectator moved on to %d after its own messages", packet.GetSpectateTarget().PlayerId)
+		case *packets.Packet_PlayerConsumed, *packets.Packet_Disconnect:
+			t.Errorf("Spectator was shown its own %T", packet.Msg)
+		}
+	}
+}
@@ -21,6 +21,58 @@ const (
 )
 
 // Define your messages
+type PowerUpKind int32
+
+const (
+	PowerUpKind_POWER_UP_UNKNOWN     PowerUpKind = 0
+	PowerUpKind_POWER_UP_SPEED_BOOST PowerUpKind = 1
+	PowerUpKind_POWER_UP_SHIELD      PowerUpKind = 2
+	PowerUpKind_POWER_UP_MAGNET      PowerUpKind = 3
+)
+
+// Enum value maps for PowerUpKind.
+var (
+	PowerUpKind_name = map[int32]string{
+		0: "POWER_UP_UNKNOWN",
+		1: "POWER_UP_SPEED_BOOST",
+		2: "POWER_UP_SHIELD",
+		3: "POWER_UP_MAGNET",
+	}
+	PowerUpKind_value = map[string]int32{
+		"POWER_UP_UNKNOWN":     0,
+		"POWER_UP_SPEED_BOOST": 1,
+		"POWER_UP_SHIELD":      2,
+		"POWER_UP_MAGNET":      3,
+	}
+)
+
+func (x PowerUpKind) Enum() *PowerUpKind {
+	p := new(PowerUpKind)
+	*p = x
+	return p
+}
+
+func (x PowerUpKind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PowerUpKind) Descriptor() protoreflect.EnumDescriptor {
+	return file_packets_proto_enumTypes[0].Descriptor()
+}
+
+func (PowerUpKind) Type() protoreflect.EnumType {
+	return &file_packets_proto_enumTypes[0]
+}
+
+func (x PowerUpKind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PowerUpKind.Descriptor instead.
+func (PowerUpKind) EnumDescriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{0}
+}
+
 type ChatMessage struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Msg           string                 `protobuf:"bytes,1,opt,name=msg,proto3" json:"msg,omitempty"`
@@ -302,17 +354,22 @@ func (x *DenyResponseMessage) GetReason() string {
 }
 
 type PlayerMessage struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	X             float64                `protobuf:"fixed64,3,opt,name=x,proto3" json:"x,omitempty"`
-	Y             float64                `protobuf:"fixed64,4,opt,name=y,proto3" json:"y,omitempty"`
-	Radius        float64                `protobuf:"fixed64,5,opt,name=radius,proto3" json:"radius,omitempty"`
-	Direction     float64                `protobuf:"fixed64,6,opt,name=direction,proto3" json:"direction,omitempty"`
-	Speed         float64                `protobuf:"fixed64,7,opt,name=speed,proto3" json:"speed,omitempty"`
-	Color         int32                  `protobuf:"varint,8,opt,name=color,proto3" json:"color,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Id                  uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name                string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	X                   float64                `protobuf:"fixed64,3,opt,name=x,proto3" json:"x,omitempty"`
+	Y                   float64                `protobuf:"fixed64,4,opt,name=y,proto3" json:"y,omitempty"`
+	Radius              float64                `protobuf:"fixed64,5,opt,name=radius,proto3" json:"radius,omitempty"`
+	Direction           float64                `protobuf:"fixed64,6,opt,name=direction,proto3" json:"direction,omitempty"`
+	Speed               float64                `protobuf:"fixed64,7,opt,name=speed,proto3" json:"speed,omitempty"`
+	Color               int32                  `protobuf:"varint,8,opt,name=color,proto3" json:"color,omitempty"`
+	IsBot               bool                   `protobuf:"varint,9,opt,name=is_bot,json=isBot,proto3" json:"is_bot,omitempty"`
+	SpawnProtected      bool                   `protobuf:"varint,10,opt,name=spawn_protected,json=spawnProtected,proto3" json:"spawn_protected,omitempty"`
+	Effects             []*EffectMessage       `protobuf:"bytes,11,rep,name=effects,proto3" json:"effects,omitempty"`
+	LastInputSequence   uint32                 `protobuf:"varint,12,opt,name=last_input_sequence,json=lastInputSequence,proto3" json:"last_input_sequence,omitempty"`
+	LastInputClientTime float64                `protobuf:"fixed64,13,opt,name=last_input_client_time,json=lastInputClientTime,proto3" json:"last_input_client_time,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
 }
 
 func (x *PlayerMessage) Reset() {
@@ -401,9 +458,46 @@ func (x *PlayerMessage) GetColor() int32 {
 	return 0
 }
 
+func (x *PlayerMessage) GetIsBot() bool {
+	if x != nil {
+		return x.IsBot
+	}
+	return false
+}
+
+func (x *PlayerMessage) GetSpawnProtected() bool {
+	if x != nil {
+		return x.SpawnProtected
+	}
+	return false
+}
+
+func (x *PlayerMessage) GetEffects() []*EffectMessage {
+	if x != nil {
+		return x.Effects
+	}
+	return nil
+}
+
+func (x *PlayerMessage) GetLastInputSequence() uint32 {
+	if x != nil {
+		return x.LastInputSequence
+	}
+	return 0
+}
+
+func (x *PlayerMessage) GetLastInputClientTime() float64 {
+	if x != nil {
+		return x.LastInputClientTime
+	}
+	return 0
+}
+
 type PlayerDirectionMessage struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Direction     float64                `protobuf:"fixed64,1,opt,name=direction,proto3" json:"direction,omitempty"`
+	Sequence      uint32                 `protobuf:"varint,2,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	ClientTime    float64                `protobuf:"fixed64,3,opt,name=client_time,json=clientTime,proto3" json:"client_time,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -445,6 +539,20 @@ func (x *PlayerDirectionMessage) GetDirection() float64 {
 	return 0
 }
 
+func (x *PlayerDirectionMessage) GetSequence() uint32 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *PlayerDirectionMessage) GetClientTime() float64 {
+	if x != nil {
+		return x.ClientTime
+	}
+	return 0
+}
+
 type SporeMessage struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -909,49 +1017,26 @@ func (x *DisconnectMessage) GetReason() string {
 	return ""
 }
 
-// Define the main Packet message
-type Packet struct {
-	state    protoimpl.MessageState `protogen:"open.v1"`
-	SenderId uint64                 `protobuf:"varint,1,opt,name=sender_id,json=senderId,proto3" json:"sender_id,omitempty"`
-	// Types that are valid to be assigned to Msg:
-	//
-	//	*Packet_Chat
-	//	*Packet_Id
-	//	*Packet_LoginRequest
-	//	*Packet_RegisterRequest
-	//	*Packet_OkResponse
-	//	*Packet_DenyResponse
-	//	*Packet_Player
-	//	*Packet_PlayerDirection
-	//	*Packet_Spore
-	//	*Packet_SporeConsumed
-	//	*Packet_SporeBatch
-	//	*Packet_PlayerConsumed
-	//	*Packet_HiscoreBoardRequest
-	//	*Packet_Hiscore
-	//	*Packet_HiscoreBoard
-	//	*Packet_FinishedBrowsingHiscores
-	//	*Packet_SearchHiscore
-	//	*Packet_Disconnect
-	Msg           isPacket_Msg `protobuf_oneof:"msg"`
+type SpectateRequestMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Packet) Reset() {
-	*x = Packet{}
+func (x *SpectateRequestMessage) Reset() {
+	*x = SpectateRequestMessage{}
 	mi := &file_packets_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Packet) String() string {
+func (x *SpectateRequestMessage) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Packet) ProtoMessage() {}
+func (*SpectateRequestMessage) ProtoMessage() {}
 
-func (x *Packet) ProtoReflect() protoreflect.Message {
+func (x *SpectateRequestMessage) ProtoReflect() protoreflect.Message {
 	mi := &file_packets_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -963,189 +1048,836 @@ func (x *Packet) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Packet.ProtoReflect.Descriptor instead.
-func (*Packet) Descriptor() ([]byte, []int) {
+// Deprecated: Use SpectateRequestMessage.ProtoReflect.Descriptor instead.
+func (*SpectateRequestMessage) Descriptor() ([]byte, []int) {
 	return file_packets_proto_rawDescGZIP(), []int{18}
 }
 
-func (x *Packet) GetSenderId() uint64 {
-	if x != nil {
-		return x.SenderId
-	}
-	return 0
+type SpectateTargetMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId      uint64                 `protobuf:"varint,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Packet) GetMsg() isPacket_Msg {
-	if x != nil {
-		return x.Msg
-	}
-	return nil
+func (x *SpectateTargetMessage) Reset() {
+	*x = SpectateTargetMessage{}
+	mi := &file_packets_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *Packet) GetChat() *ChatMessage {
-	if x != nil {
-		if x, ok := x.Msg.(*Packet_Chat); ok {
-			return x.Chat
-		}
-	}
-	return nil
+func (x *SpectateTargetMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *Packet) GetId() *IdMessage {
+func (*SpectateTargetMessage) ProtoMessage() {}
+
+func (x *SpectateTargetMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[19]
 	if x != nil {
-		if x, ok := x.Msg.(*Packet_Id); ok {
-			return x.Id
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *Packet) GetLoginRequest() *LoginRequestMessage {
-	if x != nil {
-		if x, ok := x.Msg.(*Packet_LoginRequest); ok {
-			return x.LoginRequest
-		}
-	}
-	return nil
+// Deprecated: Use SpectateTargetMessage.ProtoReflect.Descriptor instead.
+func (*SpectateTargetMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{19}
 }
 
-func (x *Packet) GetRegisterRequest() *RegisterRequestMessage {
+func (x *SpectateTargetMessage) GetPlayerId() uint64 {
 	if x != nil {
-		if x, ok := x.Msg.(*Packet_RegisterRequest); ok {
-			return x.RegisterRequest
-		}
+		return x.PlayerId
 	}
-	return nil
+	return 0
 }
 
-func (x *Packet) GetOkResponse() *OkResponseMessage {
-	if x != nil {
-		if x, ok := x.Msg.(*Packet_OkResponse); ok {
-			return x.OkResponse
-		}
-	}
-	return nil
+type FinishedSpectatingMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Packet) GetDenyResponse() *DenyResponseMessage {
-	if x != nil {
-		if x, ok := x.Msg.(*Packet_DenyResponse); ok {
-			return x.DenyResponse
-		}
-	}
-	return nil
+func (x *FinishedSpectatingMessage) Reset() {
+	*x = FinishedSpectatingMessage{}
+	mi := &file_packets_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *Packet) GetPlayer() *PlayerMessage {
-	if x != nil {
-		if x, ok := x.Msg.(*Packet_Player); ok {
-			return x.Player
-		}
-	}
-	return nil
+func (x *FinishedSpectatingMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *Packet) GetPlayerDirection() *PlayerDirectionMessage {
+func (*FinishedSpectatingMessage) ProtoMessage() {}
+
+func (x *FinishedSpectatingMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[20]
 	if x != nil {
-		if x, ok := x.Msg.(*Packet_PlayerDirection); ok {
-			return x.PlayerDirection
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *Packet) GetSpore() *SporeMessage {
-	if x != nil {
-		if x, ok := x.Msg.(*Packet_Spore); ok {
-			return x.Spore
-		}
-	}
-	return nil
+// Deprecated: Use FinishedSpectatingMessage.ProtoReflect.Descriptor instead.
+func (*FinishedSpectatingMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{20}
 }
 
-func (x *Packet) GetSporeConsumed() *SporeConsumedMessage {
-	if x != nil {
-		if x, ok := x.Msg.(*Packet_SporeConsumed); ok {
-			return x.SporeConsumed
-		}
-	}
-	return nil
+type DeathMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	KillerId      uint64                 `protobuf:"varint,1,opt,name=killer_id,json=killerId,proto3" json:"killer_id,omitempty"`
+	KillerName    string                 `protobuf:"bytes,2,opt,name=killer_name,json=killerName,proto3" json:"killer_name,omitempty"`
+	FinalMass     uint64                 `protobuf:"varint,3,opt,name=final_mass,json=finalMass,proto3" json:"final_mass,omitempty"`
+	TimeAlive     float64                `protobuf:"fixed64,4,opt,name=time_alive,json=timeAlive,proto3" json:"time_alive,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Packet) GetSporeBatch() *SporeBatchMessage {
-	if x != nil {
-		if x, ok := x.Msg.(*Packet_SporeBatch); ok {
-			return x.SporeBatch
-		}
-	}
-	return nil
+func (x *DeathMessage) Reset() {
+	*x = DeathMessage{}
+	mi := &file_packets_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *Packet) GetPlayerConsumed() *PlayerConsumedMessage {
-	if x != nil {
-		if x, ok := x.Msg.(*Packet_PlayerConsumed); ok {
-			return x.PlayerConsumed
-		}
-	}
-	return nil
+func (x *DeathMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *Packet) GetHiscoreBoardRequest() *HiscoreBoardRequestMessage {
+func (*DeathMessage) ProtoMessage() {}
+
+func (x *DeathMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[21]
 	if x != nil {
-		if x, ok := x.Msg.(*Packet_HiscoreBoardRequest); ok {
-			return x.HiscoreBoardRequest
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *Packet) GetHiscore() *HiscoreMessage {
-	if x != nil {
-		if x, ok := x.Msg.(*Packet_Hiscore); ok {
-			return x.Hiscore
-		}
-	}
-	return nil
+// Deprecated: Use DeathMessage.ProtoReflect.Descriptor instead.
+func (*DeathMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{21}
 }
 
-func (x *Packet) GetHiscoreBoard() *HiscoreBoardMessage {
+func (x *DeathMessage) GetKillerId() uint64 {
 	if x != nil {
-		if x, ok := x.Msg.(*Packet_HiscoreBoard); ok {
-			return x.HiscoreBoard
-		}
+		return x.KillerId
 	}
-	return nil
+	return 0
 }
 
-func (x *Packet) GetFinishedBrowsingHiscores() *FinishedBrowsingHiscoresMessage {
+func (x *DeathMessage) GetKillerName() string {
 	if x != nil {
-		if x, ok := x.Msg.(*Packet_FinishedBrowsingHiscores); ok {
-			return x.FinishedBrowsingHiscores
-		}
+		return x.KillerName
 	}
-	return nil
+	return ""
 }
 
-func (x *Packet) GetSearchHiscore() *SearchHiscoreMessage {
+func (x *DeathMessage) GetFinalMass() uint64 {
 	if x != nil {
-		if x, ok := x.Msg.(*Packet_SearchHiscore); ok {
-			return x.SearchHiscore
-		}
+		return x.FinalMass
 	}
-	return nil
+	return 0
 }
 
-func (x *Packet) GetDisconnect() *DisconnectMessage {
+func (x *DeathMessage) GetTimeAlive() float64 {
 	if x != nil {
-		if x, ok := x.Msg.(*Packet_Disconnect); ok {
-			return x.Disconnect
-		}
+		return x.TimeAlive
 	}
-	return nil
-}
-
-type isPacket_Msg interface {
-	isPacket_Msg()
+	return 0
+}
+
+type RespawnRequestMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RespawnRequestMessage) Reset() {
+	*x = RespawnRequestMessage{}
+	mi := &file_packets_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RespawnRequestMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RespawnRequestMessage) ProtoMessage() {}
+
+func (x *RespawnRequestMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RespawnRequestMessage.ProtoReflect.Descriptor instead.
+func (*RespawnRequestMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{22}
+}
+
+type ReturnToMenuMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReturnToMenuMessage) Reset() {
+	*x = ReturnToMenuMessage{}
+	mi := &file_packets_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReturnToMenuMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReturnToMenuMessage) ProtoMessage() {}
+
+func (x *ReturnToMenuMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReturnToMenuMessage.ProtoReflect.Descriptor instead.
+func (*ReturnToMenuMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{23}
+}
+
+type EffectMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Kind          PowerUpKind            `protobuf:"varint,1,opt,name=kind,proto3,enum=packets.PowerUpKind" json:"kind,omitempty"`
+	Remaining     float64                `protobuf:"fixed64,2,opt,name=remaining,proto3" json:"remaining,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EffectMessage) Reset() {
+	*x = EffectMessage{}
+	mi := &file_packets_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EffectMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EffectMessage) ProtoMessage() {}
+
+func (x *EffectMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EffectMessage.ProtoReflect.Descriptor instead.
+func (*EffectMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *EffectMessage) GetKind() PowerUpKind {
+	if x != nil {
+		return x.Kind
+	}
+	return PowerUpKind_POWER_UP_UNKNOWN
+}
+
+func (x *EffectMessage) GetRemaining() float64 {
+	if x != nil {
+		return x.Remaining
+	}
+	return 0
+}
+
+type PowerUpMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	X             float64                `protobuf:"fixed64,2,opt,name=x,proto3" json:"x,omitempty"`
+	Y             float64                `protobuf:"fixed64,3,opt,name=y,proto3" json:"y,omitempty"`
+	Radius        float64                `protobuf:"fixed64,4,opt,name=radius,proto3" json:"radius,omitempty"`
+	Kind          PowerUpKind            `protobuf:"varint,5,opt,name=kind,proto3,enum=packets.PowerUpKind" json:"kind,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PowerUpMessage) Reset() {
+	*x = PowerUpMessage{}
+	mi := &file_packets_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PowerUpMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PowerUpMessage) ProtoMessage() {}
+
+func (x *PowerUpMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PowerUpMessage.ProtoReflect.Descriptor instead.
+func (*PowerUpMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *PowerUpMessage) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *PowerUpMessage) GetX() float64 {
+	if x != nil {
+		return x.X
+	}
+	return 0
+}
+
+func (x *PowerUpMessage) GetY() float64 {
+	if x != nil {
+		return x.Y
+	}
+	return 0
+}
+
+func (x *PowerUpMessage) GetRadius() float64 {
+	if x != nil {
+		return x.Radius
+	}
+	return 0
+}
+
+func (x *PowerUpMessage) GetKind() PowerUpKind {
+	if x != nil {
+		return x.Kind
+	}
+	return PowerUpKind_POWER_UP_UNKNOWN
+}
+
+type PowerUpConsumedMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PowerUpId     uint64                 `protobuf:"varint,1,opt,name=power_up_id,json=powerUpId,proto3" json:"power_up_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PowerUpConsumedMessage) Reset() {
+	*x = PowerUpConsumedMessage{}
+	mi := &file_packets_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PowerUpConsumedMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PowerUpConsumedMessage) ProtoMessage() {}
+
+func (x *PowerUpConsumedMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PowerUpConsumedMessage.ProtoReflect.Descriptor instead.
+func (*PowerUpConsumedMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *PowerUpConsumedMessage) GetPowerUpId() uint64 {
+	if x != nil {
+		return x.PowerUpId
+	}
+	return 0
+}
+
+type DatagramTokenMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         uint64                 `protobuf:"varint,1,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DatagramTokenMessage) Reset() {
+	*x = DatagramTokenMessage{}
+	mi := &file_packets_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DatagramTokenMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DatagramTokenMessage) ProtoMessage() {}
+
+func (x *DatagramTokenMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DatagramTokenMessage.ProtoReflect.Descriptor instead.
+func (*DatagramTokenMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *DatagramTokenMessage) GetToken() uint64 {
+	if x != nil {
+		return x.Token
+	}
+	return 0
+}
+
+type ReplayRequestMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Speed         float64                `protobuf:"fixed64,2,opt,name=speed,proto3" json:"speed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReplayRequestMessage) Reset() {
+	*x = ReplayRequestMessage{}
+	mi := &file_packets_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplayRequestMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplayRequestMessage) ProtoMessage() {}
+
+func (x *ReplayRequestMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplayRequestMessage.ProtoReflect.Descriptor instead.
+func (*ReplayRequestMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ReplayRequestMessage) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ReplayRequestMessage) GetSpeed() float64 {
+	if x != nil {
+		return x.Speed
+	}
+	return 0
+}
+
+// Define the main Packet message
+type Packet struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	SenderId uint64                 `protobuf:"varint,1,opt,name=sender_id,json=senderId,proto3" json:"sender_id,omitempty"`
+	// Types that are valid to be assigned to Msg:
+	//
+	//	*Packet_Chat
+	//	*Packet_Id
+	//	*Packet_LoginRequest
+	//	*Packet_RegisterRequest
+	//	*Packet_OkResponse
+	//	*Packet_DenyResponse
+	//	*Packet_Player
+	//	*Packet_PlayerDirection
+	//	*Packet_Spore
+	//	*Packet_SporeConsumed
+	//	*Packet_SporeBatch
+	//	*Packet_PlayerConsumed
+	//	*Packet_HiscoreBoardRequest
+	//	*Packet_Hiscore
+	//	*Packet_HiscoreBoard
+	//	*Packet_FinishedBrowsingHiscores
+	//	*Packet_SearchHiscore
+	//	*Packet_Disconnect
+	//	*Packet_SpectateRequest
+	//	*Packet_SpectateTarget
+	//	*Packet_FinishedSpectating
+	//	*Packet_Death
+	//	*Packet_RespawnRequest
+	//	*Packet_ReturnToMenu
+	//	*Packet_PowerUp
+	//	*Packet_PowerUpConsumed
+	//	*Packet_DatagramToken
+	//	*Packet_ReplayRequest
+	Msg           isPacket_Msg `protobuf_oneof:"msg"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Packet) Reset() {
+	*x = Packet{}
+	mi := &file_packets_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Packet) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Packet) ProtoMessage() {}
+
+func (x *Packet) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Packet.ProtoReflect.Descriptor instead.
+func (*Packet) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *Packet) GetSenderId() uint64 {
+	if x != nil {
+		return x.SenderId
+	}
+	return 0
+}
+
+func (x *Packet) GetMsg() isPacket_Msg {
+	if x != nil {
+		return x.Msg
+	}
+	return nil
+}
+
+func (x *Packet) GetChat() *ChatMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_Chat); ok {
+			return x.Chat
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetId() *IdMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_Id); ok {
+			return x.Id
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetLoginRequest() *LoginRequestMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_LoginRequest); ok {
+			return x.LoginRequest
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetRegisterRequest() *RegisterRequestMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_RegisterRequest); ok {
+			return x.RegisterRequest
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetOkResponse() *OkResponseMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_OkResponse); ok {
+			return x.OkResponse
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetDenyResponse() *DenyResponseMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_DenyResponse); ok {
+			return x.DenyResponse
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetPlayer() *PlayerMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_Player); ok {
+			return x.Player
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetPlayerDirection() *PlayerDirectionMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_PlayerDirection); ok {
+			return x.PlayerDirection
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetSpore() *SporeMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_Spore); ok {
+			return x.Spore
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetSporeConsumed() *SporeConsumedMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_SporeConsumed); ok {
+			return x.SporeConsumed
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetSporeBatch() *SporeBatchMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_SporeBatch); ok {
+			return x.SporeBatch
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetPlayerConsumed() *PlayerConsumedMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_PlayerConsumed); ok {
+			return x.PlayerConsumed
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetHiscoreBoardRequest() *HiscoreBoardRequestMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_HiscoreBoardRequest); ok {
+			return x.HiscoreBoardRequest
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetHiscore() *HiscoreMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_Hiscore); ok {
+			return x.Hiscore
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetHiscoreBoard() *HiscoreBoardMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_HiscoreBoard); ok {
+			return x.HiscoreBoard
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetFinishedBrowsingHiscores() *FinishedBrowsingHiscoresMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_FinishedBrowsingHiscores); ok {
+			return x.FinishedBrowsingHiscores
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetSearchHiscore() *SearchHiscoreMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_SearchHiscore); ok {
+			return x.SearchHiscore
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetDisconnect() *DisconnectMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_Disconnect); ok {
+			return x.Disconnect
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetSpectateRequest() *SpectateRequestMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_SpectateRequest); ok {
+			return x.SpectateRequest
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetSpectateTarget() *SpectateTargetMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_SpectateTarget); ok {
+			return x.SpectateTarget
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetFinishedSpectating() *FinishedSpectatingMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_FinishedSpectating); ok {
+			return x.FinishedSpectating
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetDeath() *DeathMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_Death); ok {
+			return x.Death
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetRespawnRequest() *RespawnRequestMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_RespawnRequest); ok {
+			return x.RespawnRequest
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetReturnToMenu() *ReturnToMenuMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_ReturnToMenu); ok {
+			return x.ReturnToMenu
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetPowerUp() *PowerUpMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_PowerUp); ok {
+			return x.PowerUp
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetPowerUpConsumed() *PowerUpConsumedMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_PowerUpConsumed); ok {
+			return x.PowerUpConsumed
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetDatagramToken() *DatagramTokenMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_DatagramToken); ok {
+			return x.DatagramToken
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetReplayRequest() *ReplayRequestMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_ReplayRequest); ok {
+			return x.ReplayRequest
+		}
+	}
+	return nil
+}
+
+type isPacket_Msg interface {
+	isPacket_Msg()
 }
 
 type Packet_Chat struct {
@@ -1220,6 +1952,46 @@ type Packet_Disconnect struct {
 	Disconnect *DisconnectMessage `protobuf:"bytes,19,opt,name=disconnect,proto3,oneof"`
 }
 
+type Packet_SpectateRequest struct {
+	SpectateRequest *SpectateRequestMessage `protobuf:"bytes,20,opt,name=spectate_request,json=spectateRequest,proto3,oneof"`
+}
+
+type Packet_SpectateTarget struct {
+	SpectateTarget *SpectateTargetMessage `protobuf:"bytes,21,opt,name=spectate_target,json=spectateTarget,proto3,oneof"`
+}
+
+type Packet_FinishedSpectating struct {
+	FinishedSpectating *FinishedSpectatingMessage `protobuf:"bytes,22,opt,name=finished_spectating,json=finishedSpectating,proto3,oneof"`
+}
+
+type Packet_Death struct {
+	Death *DeathMessage `protobuf:"bytes,23,opt,name=death,proto3,oneof"`
+}
+
+type Packet_RespawnRequest struct {
+	RespawnRequest *RespawnRequestMessage `protobuf:"bytes,24,opt,name=respawn_request,json=respawnRequest,proto3,oneof"`
+}
+
+type Packet_ReturnToMenu struct {
+	ReturnToMenu *ReturnToMenuMessage `protobuf:"bytes,25,opt,name=return_to_menu,json=returnToMenu,proto3,oneof"`
+}
+
+type Packet_PowerUp struct {
+	PowerUp *PowerUpMessage `protobuf:"bytes,26,opt,name=power_up,json=powerUp,proto3,oneof"`
+}
+
+type Packet_PowerUpConsumed struct {
+	PowerUpConsumed *PowerUpConsumedMessage `protobuf:"bytes,27,opt,name=power_up_consumed,json=powerUpConsumed,proto3,oneof"`
+}
+
+type Packet_DatagramToken struct {
+	DatagramToken *DatagramTokenMessage `protobuf:"bytes,28,opt,name=datagram_token,json=datagramToken,proto3,oneof"`
+}
+
+type Packet_ReplayRequest struct {
+	ReplayRequest *ReplayRequestMessage `protobuf:"bytes,29,opt,name=replay_request,json=replayRequest,proto3,oneof"`
+}
+
 func (*Packet_Chat) isPacket_Msg() {}
 
 func (*Packet_Id) isPacket_Msg() {}
@@ -1256,6 +2028,26 @@ func (*Packet_SearchHiscore) isPacket_Msg() {}
 
 func (*Packet_Disconnect) isPacket_Msg() {}
 
+func (*Packet_SpectateRequest) isPacket_Msg() {}
+
+func (*Packet_SpectateTarget) isPacket_Msg() {}
+
+func (*Packet_FinishedSpectating) isPacket_Msg() {}
+
+func (*Packet_Death) isPacket_Msg() {}
+
+func (*Packet_RespawnRequest) isPacket_Msg() {}
+
+func (*Packet_ReturnToMenu) isPacket_Msg() {}
+
+func (*Packet_PowerUp) isPacket_Msg() {}
+
+func (*Packet_PowerUpConsumed) isPacket_Msg() {}
+
+func (*Packet_DatagramToken) isPacket_Msg() {}
+
+func (*Packet_ReplayRequest) isPacket_Msg() {}
+
 var File_packets_proto protoreflect.FileDescriptor
 
 var file_packets_proto_rawDesc = []byte{
@@ -1280,7 +2072,7 @@ var file_packets_proto_rawDesc = []byte{
 	0x67, 0x65, 0x22, 0x2d, 0x0a, 0x13, 0x44, 0x65, 0x6e, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
 	0x73, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61,
 	0x73, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f,
-	0x6e, 0x22, 0xb1, 0x01, 0x0a, 0x0d, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73,
+	0x6e, 0x22, 0x88, 0x03, 0x0a, 0x0d, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73,
 	0x61, 0x67, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52,
 	0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
 	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x0c, 0x0a, 0x01, 0x78, 0x18, 0x03, 0x20, 0x01,
@@ -1291,125 +2083,236 @@ var file_packets_proto_rawDesc = []byte{
 	0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x70, 0x65,
 	0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x73, 0x70, 0x65, 0x65, 0x64, 0x12,
 	0x14, 0x0a, 0x05, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05,
-	0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x22, 0x36, 0x0a, 0x16, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x44,
-	0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12,
-	0x1c, 0x0a, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x01, 0x52, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x52, 0x0a,
-	0x0c, 0x53, 0x70, 0x6f, 0x72, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x0e, 0x0a,
+	0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x12, 0x15, 0x0a, 0x06, 0x69, 0x73, 0x5f, 0x62, 0x6f, 0x74, 0x18,
+	0x09, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x69, 0x73, 0x42, 0x6f, 0x74, 0x12, 0x27, 0x0a, 0x0f,
+	0x73, 0x70, 0x61, 0x77, 0x6e, 0x5f, 0x70, 0x72, 0x6f, 0x74, 0x65, 0x63, 0x74, 0x65, 0x64, 0x18,
+	0x0a, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x73, 0x70, 0x61, 0x77, 0x6e, 0x50, 0x72, 0x6f, 0x74,
+	0x65, 0x63, 0x74, 0x65, 0x64, 0x12, 0x30, 0x0a, 0x07, 0x65, 0x66, 0x66, 0x65, 0x63, 0x74, 0x73,
+	0x18, 0x0b, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73,
+	0x2e, 0x45, 0x66, 0x66, 0x65, 0x63, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x07,
+	0x65, 0x66, 0x66, 0x65, 0x63, 0x74, 0x73, 0x12, 0x2e, 0x0a, 0x13, 0x6c, 0x61, 0x73, 0x74, 0x5f,
+	0x69, 0x6e, 0x70, 0x75, 0x74, 0x5f, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x0c,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x11, 0x6c, 0x61, 0x73, 0x74, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x53,
+	0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x33, 0x0a, 0x16, 0x6c, 0x61, 0x73, 0x74, 0x5f,
+	0x69, 0x6e, 0x70, 0x75, 0x74, 0x5f, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x74, 0x69, 0x6d,
+	0x65, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x01, 0x52, 0x13, 0x6c, 0x61, 0x73, 0x74, 0x49, 0x6e, 0x70,
+	0x75, 0x74, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x22, 0x73, 0x0a, 0x16,
+	0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65,
+	0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0a, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x54, 0x69, 0x6d,
+	0x65, 0x22, 0x52, 0x0a, 0x0c, 0x53, 0x70, 0x6f, 0x72, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69,
+	0x64, 0x12, 0x0c, 0x0a, 0x01, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x01, 0x78, 0x12,
+	0x0c, 0x0a, 0x01, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x01, 0x79, 0x12, 0x16, 0x0a,
+	0x06, 0x72, 0x61, 0x64, 0x69, 0x75, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x06, 0x72,
+	0x61, 0x64, 0x69, 0x75, 0x73, 0x22, 0x31, 0x0a, 0x14, 0x53, 0x70, 0x6f, 0x72, 0x65, 0x43, 0x6f,
+	0x6e, 0x73, 0x75, 0x6d, 0x65, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x19, 0x0a,
+	0x08, 0x73, 0x70, 0x6f, 0x72, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x07, 0x73, 0x70, 0x6f, 0x72, 0x65, 0x49, 0x64, 0x22, 0x42, 0x0a, 0x11, 0x53, 0x70, 0x6f, 0x72,
+	0x65, 0x42, 0x61, 0x74, 0x63, 0x68, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x2d, 0x0a,
+	0x06, 0x73, 0x70, 0x6f, 0x72, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e,
+	0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x53, 0x70, 0x6f, 0x72, 0x65, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x52, 0x06, 0x73, 0x70, 0x6f, 0x72, 0x65, 0x73, 0x22, 0x34, 0x0a, 0x15,
+	0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x73, 0x75, 0x6d, 0x65, 0x64, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x5f,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72,
+	0x49, 0x64, 0x22, 0x1c, 0x0a, 0x1a, 0x48, 0x69, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x42, 0x6f, 0x61,
+	0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x22, 0x4e, 0x0a, 0x0e, 0x48, 0x69, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x61, 0x6e, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x04, 0x72, 0x61, 0x6e, 0x6b, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x63,
+	0x6f, 0x72, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65,
+	0x22, 0x4a, 0x0a, 0x13, 0x48, 0x69, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x42, 0x6f, 0x61, 0x72, 0x64,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x33, 0x0a, 0x08, 0x68, 0x69, 0x73, 0x63, 0x6f,
+	0x72, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x70, 0x61, 0x63, 0x6b,
+	0x65, 0x74, 0x73, 0x2e, 0x48, 0x69, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x52, 0x08, 0x68, 0x69, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x73, 0x22, 0x21, 0x0a, 0x1f,
+	0x46, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x65, 0x64, 0x42, 0x72, 0x6f, 0x77, 0x73, 0x69, 0x6e, 0x67,
+	0x48, 0x69, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x73, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22,
+	0x2a, 0x0a, 0x14, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x48, 0x69, 0x73, 0x63, 0x6f, 0x72, 0x65,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x2b, 0x0a, 0x11, 0x44,
+	0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x18, 0x0a, 0x16, 0x53, 0x70, 0x65, 0x63,
+	0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x22, 0x34, 0x0a, 0x15, 0x53, 0x70, 0x65, 0x63, 0x74, 0x61, 0x74, 0x65, 0x54, 0x61,
+	0x72, 0x67, 0x65, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x70,
+	0x6c, 0x61, 0x79, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08,
+	0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x49, 0x64, 0x22, 0x1b, 0x0a, 0x19, 0x46, 0x69, 0x6e, 0x69,
+	0x73, 0x68, 0x65, 0x64, 0x53, 0x70, 0x65, 0x63, 0x74, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x8a, 0x01, 0x0a, 0x0c, 0x44, 0x65, 0x61, 0x74, 0x68, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x6b, 0x69, 0x6c, 0x6c, 0x65, 0x72,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x6b, 0x69, 0x6c, 0x6c, 0x65,
+	0x72, 0x49, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x6b, 0x69, 0x6c, 0x6c, 0x65, 0x72, 0x5f, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6b, 0x69, 0x6c, 0x6c, 0x65, 0x72,
+	0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x66, 0x69, 0x6e, 0x61, 0x6c, 0x5f, 0x6d, 0x61,
+	0x73, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x66, 0x69, 0x6e, 0x61, 0x6c, 0x4d,
+	0x61, 0x73, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x61, 0x6c, 0x69, 0x76,
+	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x41, 0x6c, 0x69,
+	0x76, 0x65, 0x22, 0x17, 0x0a, 0x15, 0x52, 0x65, 0x73, 0x70, 0x61, 0x77, 0x6e, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x15, 0x0a, 0x13, 0x52,
+	0x65, 0x74, 0x75, 0x72, 0x6e, 0x54, 0x6f, 0x4d, 0x65, 0x6e, 0x75, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x22, 0x57, 0x0a, 0x0d, 0x45, 0x66, 0x66, 0x65, 0x63, 0x74, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x12, 0x28, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x14, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x50, 0x6f, 0x77, 0x65,
+	0x72, 0x55, 0x70, 0x4b, 0x69, 0x6e, 0x64, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x1c, 0x0a,
+	0x09, 0x72, 0x65, 0x6d, 0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x09, 0x72, 0x65, 0x6d, 0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x22, 0x7e, 0x0a, 0x0e, 0x50,
+	0x6f, 0x77, 0x65, 0x72, 0x55, 0x70, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x0e, 0x0a,
 	0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x12, 0x0c, 0x0a,
 	0x01, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x01, 0x78, 0x12, 0x0c, 0x0a, 0x01, 0x79,
 	0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x01, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x61, 0x64,
 	0x69, 0x75, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x06, 0x72, 0x61, 0x64, 0x69, 0x75,
-	0x73, 0x22, 0x31, 0x0a, 0x14, 0x53, 0x70, 0x6f, 0x72, 0x65, 0x43, 0x6f, 0x6e, 0x73, 0x75, 0x6d,
-	0x65, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x73, 0x70, 0x6f,
-	0x72, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x73, 0x70, 0x6f,
-	0x72, 0x65, 0x49, 0x64, 0x22, 0x42, 0x0a, 0x11, 0x53, 0x70, 0x6f, 0x72, 0x65, 0x42, 0x61, 0x74,
-	0x63, 0x68, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x2d, 0x0a, 0x06, 0x73, 0x70, 0x6f,
-	0x72, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x70, 0x61, 0x63, 0x6b,
-	0x65, 0x74, 0x73, 0x2e, 0x53, 0x70, 0x6f, 0x72, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
-	0x52, 0x06, 0x73, 0x70, 0x6f, 0x72, 0x65, 0x73, 0x22, 0x34, 0x0a, 0x15, 0x50, 0x6c, 0x61, 0x79,
-	0x65, 0x72, 0x43, 0x6f, 0x6e, 0x73, 0x75, 0x6d, 0x65, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
-	0x65, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x49, 0x64, 0x22, 0x1c,
-	0x0a, 0x1a, 0x48, 0x69, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x42, 0x6f, 0x61, 0x72, 0x64, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x4e, 0x0a, 0x0e,
-	0x48, 0x69, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x12,
-	0x0a, 0x04, 0x72, 0x61, 0x6e, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x72, 0x61,
-	0x6e, 0x6b, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x22, 0x4a, 0x0a, 0x13,
-	0x48, 0x69, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x42, 0x6f, 0x61, 0x72, 0x64, 0x4d, 0x65, 0x73, 0x73,
-	0x61, 0x67, 0x65, 0x12, 0x33, 0x0a, 0x08, 0x68, 0x69, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x73, 0x18,
-	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e,
-	0x48, 0x69, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x08,
-	0x68, 0x69, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x73, 0x22, 0x21, 0x0a, 0x1f, 0x46, 0x69, 0x6e, 0x69,
-	0x73, 0x68, 0x65, 0x64, 0x42, 0x72, 0x6f, 0x77, 0x73, 0x69, 0x6e, 0x67, 0x48, 0x69, 0x73, 0x63,
-	0x6f, 0x72, 0x65, 0x73, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x2a, 0x0a, 0x14, 0x53,
-	0x65, 0x61, 0x72, 0x63, 0x68, 0x48, 0x69, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x4d, 0x65, 0x73, 0x73,
-	0x61, 0x67, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x2b, 0x0a, 0x11, 0x44, 0x69, 0x73, 0x63, 0x6f,
-	0x6e, 0x6e, 0x65, 0x63, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x16, 0x0a, 0x06,
-	0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65,
-	0x61, 0x73, 0x6f, 0x6e, 0x22, 0xdb, 0x09, 0x0a, 0x06, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x12,
-	0x1b, 0x0a, 0x09, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x04, 0x52, 0x08, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x49, 0x64, 0x12, 0x2a, 0x0a, 0x04,
-	0x63, 0x68, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x70, 0x61, 0x63,
-	0x6b, 0x65, 0x74, 0x73, 0x2e, 0x43, 0x68, 0x61, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
-	0x48, 0x00, 0x52, 0x04, 0x63, 0x68, 0x61, 0x74, 0x12, 0x24, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x49,
-	0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x02, 0x69, 0x64, 0x12, 0x43,
-	0x0a, 0x0d, 0x6c, 0x6f, 0x67, 0x69, 0x6e, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18,
-	0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e,
-	0x4c, 0x6f, 0x67, 0x69, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4d, 0x65, 0x73, 0x73,
-	0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x0c, 0x6c, 0x6f, 0x67, 0x69, 0x6e, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x12, 0x4c, 0x0a, 0x10, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x5f,
-	0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e,
-	0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00,
-	0x52, 0x0f, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x12, 0x3d, 0x0a, 0x0b, 0x6f, 0x6b, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73,
-	0x2e, 0x4f, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61,
-	0x67, 0x65, 0x48, 0x00, 0x52, 0x0a, 0x6f, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x43, 0x0a, 0x0d, 0x64, 0x65, 0x6e, 0x79, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74,
-	0x73, 0x2e, 0x44, 0x65, 0x6e, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x4d, 0x65,
-	0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x0c, 0x64, 0x65, 0x6e, 0x79, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x30, 0x0a, 0x06, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x18,
-	0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e,
-	0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52,
-	0x06, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x12, 0x4c, 0x0a, 0x10, 0x70, 0x6c, 0x61, 0x79, 0x65,
-	0x72, 0x5f, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x09, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x1f, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x50, 0x6c, 0x61, 0x79,
-	0x65, 0x72, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x65, 0x73, 0x73, 0x61,
-	0x67, 0x65, 0x48, 0x00, 0x52, 0x0f, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x44, 0x69, 0x72, 0x65,
-	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2d, 0x0a, 0x05, 0x73, 0x70, 0x6f, 0x72, 0x65, 0x18, 0x0a,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x53,
-	0x70, 0x6f, 0x72, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x05, 0x73,
-	0x70, 0x6f, 0x72, 0x65, 0x12, 0x46, 0x0a, 0x0e, 0x73, 0x70, 0x6f, 0x72, 0x65, 0x5f, 0x63, 0x6f,
-	0x6e, 0x73, 0x75, 0x6d, 0x65, 0x64, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x70,
-	0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x53, 0x70, 0x6f, 0x72, 0x65, 0x43, 0x6f, 0x6e, 0x73,
-	0x75, 0x6d, 0x65, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x0d, 0x73,
-	0x70, 0x6f, 0x72, 0x65, 0x43, 0x6f, 0x6e, 0x73, 0x75, 0x6d, 0x65, 0x64, 0x12, 0x3d, 0x0a, 0x0b,
-	0x73, 0x70, 0x6f, 0x72, 0x65, 0x5f, 0x62, 0x61, 0x74, 0x63, 0x68, 0x18, 0x0c, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x1a, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x53, 0x70, 0x6f, 0x72,
-	0x65, 0x42, 0x61, 0x74, 0x63, 0x68, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52,
-	0x0a, 0x73, 0x70, 0x6f, 0x72, 0x65, 0x42, 0x61, 0x74, 0x63, 0x68, 0x12, 0x49, 0x0a, 0x0f, 0x70,
-	0x6c, 0x61, 0x79, 0x65, 0x72, 0x5f, 0x63, 0x6f, 0x6e, 0x73, 0x75, 0x6d, 0x65, 0x64, 0x18, 0x0d,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x50,
-	0x6c, 0x61, 0x79, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x73, 0x75, 0x6d, 0x65, 0x64, 0x4d, 0x65, 0x73,
-	0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x0e, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x43, 0x6f,
-	0x6e, 0x73, 0x75, 0x6d, 0x65, 0x64, 0x12, 0x59, 0x0a, 0x15, 0x68, 0x69, 0x73, 0x63, 0x6f, 0x72,
-	0x65, 0x5f, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18,
-	0x0e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e,
-	0x48, 0x69, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x42, 0x6f, 0x61, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x13, 0x68, 0x69,
-	0x73, 0x63, 0x6f, 0x72, 0x65, 0x42, 0x6f, 0x61, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x12, 0x33, 0x0a, 0x07, 0x68, 0x69, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x0f, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x17, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x48, 0x69, 0x73,
-	0x63, 0x6f, 0x72, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x07, 0x68,
-	0x69, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x43, 0x0a, 0x0d, 0x68, 0x69, 0x73, 0x63, 0x6f, 0x72,
-	0x65, 0x5f, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x18, 0x10, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e,
-	0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x48, 0x69, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x42,
-	0x6f, 0x61, 0x72, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x0c, 0x68,
-	0x69, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x42, 0x6f, 0x61, 0x72, 0x64, 0x12, 0x68, 0x0a, 0x1a, 0x66,
-	0x69, 0x6e, 0x69, 0x73, 0x68, 0x65, 0x64, 0x5f, 0x62, 0x72, 0x6f, 0x77, 0x73, 0x69, 0x6e, 0x67,
-	0x5f, 0x68, 0x69, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x73, 0x18, 0x11, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x28, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x46, 0x69, 0x6e, 0x69, 0x73, 0x68,
-	0x65, 0x64, 0x42, 0x72, 0x6f, 0x77, 0x73, 0x69, 0x6e, 0x67, 0x48, 0x69, 0x73, 0x63, 0x6f, 0x72,
-	0x65, 0x73, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x18, 0x66, 0x69, 0x6e,
-	0x69, 0x73, 0x68, 0x65, 0x64, 0x42, 0x72, 0x6f, 0x77, 0x73, 0x69, 0x6e, 0x67, 0x48, 0x69, 0x73,
-	0x63, 0x6f, 0x72, 0x65, 0x73, 0x12, 0x46, 0x0a, 0x0e, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x5f,
-	0x68, 0x69, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x12, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e,
-	0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x48, 0x69,
-	0x73, 0x63, 0x6f, 0x72, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x0d,
-	0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x48, 0x69, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x3c, 0x0a,
-	0x0a, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x18, 0x13, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x1a, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x44, 0x69, 0x73, 0x63,
-	0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52,
-	0x0a, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x42, 0x05, 0x0a, 0x03, 0x6d,
-	0x73, 0x67, 0x42, 0x0d, 0x5a, 0x0b, 0x70, 0x6b, 0x67, 0x2f, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74,
-	0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x73, 0x12, 0x28, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x14, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x50, 0x6f, 0x77, 0x65, 0x72, 0x55,
+	0x70, 0x4b, 0x69, 0x6e, 0x64, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x22, 0x38, 0x0a, 0x16, 0x50,
+	0x6f, 0x77, 0x65, 0x72, 0x55, 0x70, 0x43, 0x6f, 0x6e, 0x73, 0x75, 0x6d, 0x65, 0x64, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1e, 0x0a, 0x0b, 0x70, 0x6f, 0x77, 0x65, 0x72, 0x5f, 0x75,
+	0x70, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x70, 0x6f, 0x77, 0x65,
+	0x72, 0x55, 0x70, 0x49, 0x64, 0x22, 0x2c, 0x0a, 0x14, 0x44, 0x61, 0x74, 0x61, 0x67, 0x72, 0x61,
+	0x6d, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x14, 0x0a,
+	0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x74, 0x6f,
+	0x6b, 0x65, 0x6e, 0x22, 0x40, 0x0a, 0x14, 0x52, 0x65, 0x70, 0x6c, 0x61, 0x79, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x14, 0x0a, 0x05, 0x73, 0x70, 0x65, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05,
+	0x73, 0x70, 0x65, 0x65, 0x64, 0x22, 0xa0, 0x0f, 0x0a, 0x06, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74,
+	0x12, 0x1b, 0x0a, 0x09, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x08, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x49, 0x64, 0x12, 0x2a, 0x0a,
+	0x04, 0x63, 0x68, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x70, 0x61,
+	0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x43, 0x68, 0x61, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x48, 0x00, 0x52, 0x04, 0x63, 0x68, 0x61, 0x74, 0x12, 0x24, 0x0a, 0x02, 0x69, 0x64, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e,
+	0x49, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x02, 0x69, 0x64, 0x12,
+	0x43, 0x0a, 0x0d, 0x6c, 0x6f, 0x67, 0x69, 0x6e, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73,
+	0x2e, 0x4c, 0x6f, 0x67, 0x69, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x0c, 0x6c, 0x6f, 0x67, 0x69, 0x6e, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x4c, 0x0a, 0x10, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72,
+	0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f,
+	0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65,
+	0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48,
+	0x00, 0x52, 0x0f, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x3d, 0x0a, 0x0b, 0x6f, 0x6b, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74,
+	0x73, 0x2e, 0x4f, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x0a, 0x6f, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x43, 0x0a, 0x0d, 0x64, 0x65, 0x6e, 0x79, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65,
+	0x74, 0x73, 0x2e, 0x44, 0x65, 0x6e, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x0c, 0x64, 0x65, 0x6e, 0x79, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x30, 0x0a, 0x06, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73,
+	0x2e, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00,
+	0x52, 0x06, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x12, 0x4c, 0x0a, 0x10, 0x70, 0x6c, 0x61, 0x79,
+	0x65, 0x72, 0x5f, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x09, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x50, 0x6c, 0x61,
+	0x79, 0x65, 0x72, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x0f, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x44, 0x69, 0x72,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2d, 0x0a, 0x05, 0x73, 0x70, 0x6f, 0x72, 0x65, 0x18,
+	0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e,
+	0x53, 0x70, 0x6f, 0x72, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x05,
+	0x73, 0x70, 0x6f, 0x72, 0x65, 0x12, 0x46, 0x0a, 0x0e, 0x73, 0x70, 0x6f, 0x72, 0x65, 0x5f, 0x63,
+	0x6f, 0x6e, 0x73, 0x75, 0x6d, 0x65, 0x64, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e,
+	0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x53, 0x70, 0x6f, 0x72, 0x65, 0x43, 0x6f, 0x6e,
+	0x73, 0x75, 0x6d, 0x65, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x0d,
+	0x73, 0x70, 0x6f, 0x72, 0x65, 0x43, 0x6f, 0x6e, 0x73, 0x75, 0x6d, 0x65, 0x64, 0x12, 0x3d, 0x0a,
+	0x0b, 0x73, 0x70, 0x6f, 0x72, 0x65, 0x5f, 0x62, 0x61, 0x74, 0x63, 0x68, 0x18, 0x0c, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x53, 0x70, 0x6f,
+	0x72, 0x65, 0x42, 0x61, 0x74, 0x63, 0x68, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00,
+	0x52, 0x0a, 0x73, 0x70, 0x6f, 0x72, 0x65, 0x42, 0x61, 0x74, 0x63, 0x68, 0x12, 0x49, 0x0a, 0x0f,
+	0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x5f, 0x63, 0x6f, 0x6e, 0x73, 0x75, 0x6d, 0x65, 0x64, 0x18,
+	0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e,
+	0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x73, 0x75, 0x6d, 0x65, 0x64, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x0e, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x43,
+	0x6f, 0x6e, 0x73, 0x75, 0x6d, 0x65, 0x64, 0x12, 0x59, 0x0a, 0x15, 0x68, 0x69, 0x73, 0x63, 0x6f,
+	0x72, 0x65, 0x5f, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x18, 0x0e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73,
+	0x2e, 0x48, 0x69, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x42, 0x6f, 0x61, 0x72, 0x64, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x13, 0x68,
+	0x69, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x42, 0x6f, 0x61, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x33, 0x0a, 0x07, 0x68, 0x69, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x0f, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x48, 0x69,
+	0x73, 0x63, 0x6f, 0x72, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x07,
+	0x68, 0x69, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x43, 0x0a, 0x0d, 0x68, 0x69, 0x73, 0x63, 0x6f,
+	0x72, 0x65, 0x5f, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x18, 0x10, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c,
+	0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x48, 0x69, 0x73, 0x63, 0x6f, 0x72, 0x65,
+	0x42, 0x6f, 0x61, 0x72, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x0c,
+	0x68, 0x69, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x42, 0x6f, 0x61, 0x72, 0x64, 0x12, 0x68, 0x0a, 0x1a,
+	0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x65, 0x64, 0x5f, 0x62, 0x72, 0x6f, 0x77, 0x73, 0x69, 0x6e,
+	0x67, 0x5f, 0x68, 0x69, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x73, 0x18, 0x11, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x28, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x46, 0x69, 0x6e, 0x69, 0x73,
+	0x68, 0x65, 0x64, 0x42, 0x72, 0x6f, 0x77, 0x73, 0x69, 0x6e, 0x67, 0x48, 0x69, 0x73, 0x63, 0x6f,
+	0x72, 0x65, 0x73, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x18, 0x66, 0x69,
+	0x6e, 0x69, 0x73, 0x68, 0x65, 0x64, 0x42, 0x72, 0x6f, 0x77, 0x73, 0x69, 0x6e, 0x67, 0x48, 0x69,
+	0x73, 0x63, 0x6f, 0x72, 0x65, 0x73, 0x12, 0x46, 0x0a, 0x0e, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68,
+	0x5f, 0x68, 0x69, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x12, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d,
+	0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x48,
+	0x69, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52,
+	0x0d, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x48, 0x69, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x3c,
+	0x0a, 0x0a, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x18, 0x13, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x44, 0x69, 0x73,
+	0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00,
+	0x52, 0x0a, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x12, 0x4c, 0x0a, 0x10,
+	0x73, 0x70, 0x65, 0x63, 0x74, 0x61, 0x74, 0x65, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x18, 0x14, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73,
+	0x2e, 0x53, 0x70, 0x65, 0x63, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x0f, 0x73, 0x70, 0x65, 0x63, 0x74,
+	0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x49, 0x0a, 0x0f, 0x73, 0x70,
+	0x65, 0x63, 0x74, 0x61, 0x74, 0x65, 0x5f, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x15, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x53, 0x70,
+	0x65, 0x63, 0x74, 0x61, 0x74, 0x65, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x0e, 0x73, 0x70, 0x65, 0x63, 0x74, 0x61, 0x74, 0x65, 0x54,
+	0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x55, 0x0a, 0x13, 0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x65,
+	0x64, 0x5f, 0x73, 0x70, 0x65, 0x63, 0x74, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x18, 0x16, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x22, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x46, 0x69, 0x6e,
+	0x69, 0x73, 0x68, 0x65, 0x64, 0x53, 0x70, 0x65, 0x63, 0x74, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x12, 0x66, 0x69, 0x6e, 0x69, 0x73, 0x68,
+	0x65, 0x64, 0x53, 0x70, 0x65, 0x63, 0x74, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x12, 0x2d, 0x0a, 0x05,
+	0x64, 0x65, 0x61, 0x74, 0x68, 0x18, 0x17, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x70, 0x61,
+	0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x44, 0x65, 0x61, 0x74, 0x68, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x48, 0x00, 0x52, 0x05, 0x64, 0x65, 0x61, 0x74, 0x68, 0x12, 0x49, 0x0a, 0x0f, 0x72,
+	0x65, 0x73, 0x70, 0x61, 0x77, 0x6e, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x18,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x52,
+	0x65, 0x73, 0x70, 0x61, 0x77, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x0e, 0x72, 0x65, 0x73, 0x70, 0x61, 0x77, 0x6e, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x44, 0x0a, 0x0e, 0x72, 0x65, 0x74, 0x75, 0x72, 0x6e,
+	0x5f, 0x74, 0x6f, 0x5f, 0x6d, 0x65, 0x6e, 0x75, 0x18, 0x19, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c,
+	0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x52, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x54,
+	0x6f, 0x4d, 0x65, 0x6e, 0x75, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x0c,
+	0x72, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x54, 0x6f, 0x4d, 0x65, 0x6e, 0x75, 0x12, 0x34, 0x0a, 0x08,
+	0x70, 0x6f, 0x77, 0x65, 0x72, 0x5f, 0x75, 0x70, 0x18, 0x1a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17,
+	0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x50, 0x6f, 0x77, 0x65, 0x72, 0x55, 0x70,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x07, 0x70, 0x6f, 0x77, 0x65, 0x72,
+	0x55, 0x70, 0x12, 0x4d, 0x0a, 0x11, 0x70, 0x6f, 0x77, 0x65, 0x72, 0x5f, 0x75, 0x70, 0x5f, 0x63,
+	0x6f, 0x6e, 0x73, 0x75, 0x6d, 0x65, 0x64, 0x18, 0x1b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e,
+	0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x50, 0x6f, 0x77, 0x65, 0x72, 0x55, 0x70, 0x43,
+	0x6f, 0x6e, 0x73, 0x75, 0x6d, 0x65, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00,
+	0x52, 0x0f, 0x70, 0x6f, 0x77, 0x65, 0x72, 0x55, 0x70, 0x43, 0x6f, 0x6e, 0x73, 0x75, 0x6d, 0x65,
+	0x64, 0x12, 0x46, 0x0a, 0x0e, 0x64, 0x61, 0x74, 0x61, 0x67, 0x72, 0x61, 0x6d, 0x5f, 0x74, 0x6f,
+	0x6b, 0x65, 0x6e, 0x18, 0x1c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x70, 0x61, 0x63, 0x6b,
+	0x65, 0x74, 0x73, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x67, 0x72, 0x61, 0x6d, 0x54, 0x6f, 0x6b, 0x65,
+	0x6e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x0d, 0x64, 0x61, 0x74, 0x61,
+	0x67, 0x72, 0x61, 0x6d, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x46, 0x0a, 0x0e, 0x72, 0x65, 0x70,
+	0x6c, 0x61, 0x79, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x1d, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1d, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x52, 0x65, 0x70, 0x6c,
+	0x61, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x48, 0x00, 0x52, 0x0d, 0x72, 0x65, 0x70, 0x6c, 0x61, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x42, 0x05, 0x0a, 0x03, 0x6d, 0x73, 0x67, 0x2a, 0x67, 0x0a, 0x0b, 0x50, 0x6f, 0x77, 0x65,
+	0x72, 0x55, 0x70, 0x4b, 0x69, 0x6e, 0x64, 0x12, 0x14, 0x0a, 0x10, 0x50, 0x4f, 0x57, 0x45, 0x52,
+	0x5f, 0x55, 0x50, 0x5f, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x18, 0x0a,
+	0x14, 0x50, 0x4f, 0x57, 0x45, 0x52, 0x5f, 0x55, 0x50, 0x5f, 0x53, 0x50, 0x45, 0x45, 0x44, 0x5f,
+	0x42, 0x4f, 0x4f, 0x53, 0x54, 0x10, 0x01, 0x12, 0x13, 0x0a, 0x0f, 0x50, 0x4f, 0x57, 0x45, 0x52,
+	0x5f, 0x55, 0x50, 0x5f, 0x53, 0x48, 0x49, 0x45, 0x4c, 0x44, 0x10, 0x02, 0x12, 0x13, 0x0a, 0x0f,
+	0x50, 0x4f, 0x57, 0x45, 0x52, 0x5f, 0x55, 0x50, 0x5f, 0x4d, 0x41, 0x47, 0x4e, 0x45, 0x54, 0x10,
+	0x03, 0x42, 0x0d, 0x5a, 0x0b, 0x70, 0x6b, 0x67, 0x2f, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1424,54 +2327,80 @@ func file_packets_proto_rawDescGZIP() []byte {
 	return file_packets_proto_rawDescData
 }
 
-var file_packets_proto_msgTypes = make([]protoimpl.MessageInfo, 19)
+var file_packets_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_packets_proto_msgTypes = make([]protoimpl.MessageInfo, 30)
 var file_packets_proto_goTypes = []any{
-	(*ChatMessage)(nil),                     // 0: packets.ChatMessage
-	(*IdMessage)(nil),                       // 1: packets.IdMessage
-	(*LoginRequestMessage)(nil),             // 2: packets.LoginRequestMessage
-	(*RegisterRequestMessage)(nil),          // 3: packets.RegisterRequestMessage
-	(*OkResponseMessage)(nil),               // 4: packets.OkResponseMessage
-	(*DenyResponseMessage)(nil),             // 5: packets.DenyResponseMessage
-	(*PlayerMessage)(nil),                   // 6: packets.PlayerMessage
-	(*PlayerDirectionMessage)(nil),          // 7: packets.PlayerDirectionMessage
-	(*SporeMessage)(nil),                    // 8: packets.SporeMessage
-	(*SporeConsumedMessage)(nil),            // 9: packets.SporeConsumedMessage
-	(*SporeBatchMessage)(nil),               // 10: packets.SporeBatchMessage
-	(*PlayerConsumedMessage)(nil),           // 11: packets.PlayerConsumedMessage
-	(*HiscoreBoardRequestMessage)(nil),      // 12: packets.HiscoreBoardRequestMessage
-	(*HiscoreMessage)(nil),                  // 13: packets.HiscoreMessage
-	(*HiscoreBoardMessage)(nil),             // 14: packets.HiscoreBoardMessage
-	(*FinishedBrowsingHiscoresMessage)(nil), // 15: packets.FinishedBrowsingHiscoresMessage
-	(*SearchHiscoreMessage)(nil),            // 16: packets.SearchHiscoreMessage
-	(*DisconnectMessage)(nil),               // 17: packets.DisconnectMessage
-	(*Packet)(nil),                          // 18: packets.Packet
+	(PowerUpKind)(0),                        // 0: packets.PowerUpKind
+	(*ChatMessage)(nil),                     // 1: packets.ChatMessage
+	(*IdMessage)(nil),                       // 2: packets.IdMessage
+	(*LoginRequestMessage)(nil),             // 3: packets.LoginRequestMessage
+	(*RegisterRequestMessage)(nil),          // 4: packets.RegisterRequestMessage
+	(*OkResponseMessage)(nil),               // 5: packets.OkResponseMessage
+	(*DenyResponseMessage)(nil),             // 6: packets.DenyResponseMessage
+	(*PlayerMessage)(nil),                   // 7: packets.PlayerMessage
+	(*PlayerDirectionMessage)(nil),          // 8: packets.PlayerDirectionMessage
+	(*SporeMessage)(nil),                    // 9: packets.SporeMessage
+	(*SporeConsumedMessage)(nil),            // 10: packets.SporeConsumedMessage
+	(*SporeBatchMessage)(nil),               // 11: packets.SporeBatchMessage
+	(*PlayerConsumedMessage)(nil),           // 12: packets.PlayerConsumedMessage
+	(*HiscoreBoardRequestMessage)(nil),      // 13: packets.HiscoreBoardRequestMessage
+	(*HiscoreMessage)(nil),                  // 14: packets.HiscoreMessage
+	(*HiscoreBoardMessage)(nil),             // 15: packets.HiscoreBoardMessage
+	(*FinishedBrowsingHiscoresMessage)(nil), // 16: packets.FinishedBrowsingHiscoresMessage
+	(*SearchHiscoreMessage)(nil),            // 17: packets.SearchHiscoreMessage
+	(*DisconnectMessage)(nil),               // 18: packets.DisconnectMessage
+	(*SpectateRequestMessage)(nil),          // 19: packets.SpectateRequestMessage
+	(*SpectateTargetMessage)(nil),           // 20: packets.SpectateTargetMessage
+	(*FinishedSpectatingMessage)(nil),       // 21: packets.FinishedSpectatingMessage
+	(*DeathMessage)(nil),                    // 22: packets.DeathMessage
+	(*RespawnRequestMessage)(nil),           // 23: packets.RespawnRequestMessage
+	(*ReturnToMenuMessage)(nil),             // 24: packets.ReturnToMenuMessage
+	(*EffectMessage)(nil),                   // 25: packets.EffectMessage
+	(*PowerUpMessage)(nil),                  // 26: packets.PowerUpMessage
+	(*PowerUpConsumedMessage)(nil),          // 27: packets.PowerUpConsumedMessage
+	(*DatagramTokenMessage)(nil),            // 28: packets.DatagramTokenMessage
+	(*ReplayRequestMessage)(nil),            // 29: packets.ReplayRequestMessage
+	(*Packet)(nil),                          // 30: packets.Packet
 }
 var file_packets_proto_depIdxs = []int32{
-	8,  // 0: packets.SporeBatchMessage.spores:type_name -> packets.SporeMessage
-	13, // 1: packets.HiscoreBoardMessage.hiscores:type_name -> packets.HiscoreMessage
-	0,  // 2: packets.Packet.chat:type_name -> packets.ChatMessage
-	1,  // 3: packets.Packet.id:type_name -> packets.IdMessage
-	2,  // 4: packets.Packet.login_request:type_name -> packets.LoginRequestMessage
-	3,  // 5: packets.Packet.register_request:type_name -> packets.RegisterRequestMessage
-	4,  // 6: packets.Packet.ok_response:type_name -> packets.OkResponseMessage
-	5,  // 7: packets.Packet.deny_response:type_name -> packets.DenyResponseMessage
-	6,  // 8: packets.Packet.player:type_name -> packets.PlayerMessage
-	7,  // 9: packets.Packet.player_direction:type_name -> packets.PlayerDirectionMessage
-	8,  // 10: packets.Packet.spore:type_name -> packets.SporeMessage
-	9,  // 11: packets.Packet.spore_consumed:type_name -> packets.SporeConsumedMessage
-	10, // 12: packets.Packet.spore_batch:type_name -> packets.SporeBatchMessage
-	11, // 13: packets.Packet.player_consumed:type_name -> packets.PlayerConsumedMessage
-	12, // 14: packets.Packet.hiscore_board_request:type_name -> packets.HiscoreBoardRequestMessage
-	13, // 15: packets.Packet.hiscore:type_name -> packets.HiscoreMessage
-	14, // 16: packets.Packet.hiscore_board:type_name -> packets.HiscoreBoardMessage
-	15, // 17: packets.Packet.finished_browsing_hiscores:type_name -> packets.FinishedBrowsingHiscoresMessage
-	16, // 18: packets.Packet.search_hiscore:type_name -> packets.SearchHiscoreMessage
-	17, // 19: packets.Packet.disconnect:type_name -> packets.DisconnectMessage
-	20, // [20:20] is the sub-list for method output_type
-	20, // [20:20] is the sub-list for method input_type
-	20, // [20:20] is the sub-list for extension type_name
-	20, // [20:20] is the sub-list for extension extendee
-	0,  // [0:20] is the sub-list for field type_name
+	25, // 0: packets.PlayerMessage.effects:type_name -> packets.EffectMessage
+	9,  // 1: packets.SporeBatchMessage.spores:type_name -> packets.SporeMessage
+	14, // 2: packets.HiscoreBoardMessage.hiscores:type_name -> packets.HiscoreMessage
+	0,  // 3: packets.EffectMessage.kind:type_name -> packets.PowerUpKind
+	0,  // 4: packets.PowerUpMessage.kind:type_name -> packets.PowerUpKind
+	1,  // 5: packets.Packet.chat:type_name -> packets.ChatMessage
+	2,  // 6: packets.Packet.id:type_name -> packets.IdMessage
+	3,  // 7: packets.Packet.login_request:type_name -> packets.LoginRequestMessage
+	4,  // 8: packets.Packet.register_request:type_name -> packets.RegisterRequestMessage
+	5,  // 9: packets.Packet.ok_response:type_name -> packets.OkResponseMessage
+	6,  // 10: packets.Packet.deny_response:type_name -> packets.DenyResponseMessage
+	7,  // 11: packets.Packet.player:type_name -> packets.PlayerMessage
+	8,  // 12: packets.Packet.player_direction:type_name -> packets.PlayerDirectionMessage
+	9,  // 13: packets.Packet.spore:type_name -> packets.SporeMessage
+	10, // 14: packets.Packet.spore_consumed:type_name -> packets.SporeConsumedMessage
+	11, // 15: packets.Packet.spore_batch:type_name -> packets.SporeBatchMessage
+	12, // 16: packets.Packet.player_consumed:type_name -> packets.PlayerConsumedMessage
+	13, // 17: packets.Packet.hiscore_board_request:type_name -> packets.HiscoreBoardRequestMessage
+	14, // 18: packets.Packet.hiscore:type_name -> packets.HiscoreMessage
+	15, // 19: packets.Packet.hiscore_board:type_name -> packets.HiscoreBoardMessage
+	16, // 20: packets.Packet.finished_browsing_hiscores:type_name -> packets.FinishedBrowsingHiscoresMessage
+	17, // 21: packets.Packet.search_hiscore:type_name -> packets.SearchHiscoreMessage
+	18, // 22: packets.Packet.disconnect:type_name -> packets.DisconnectMessage
+	19, // 23: packets.Packet.spectate_request:type_name -> packets.SpectateRequestMessage
+	20, // 24: packets.Packet.spectate_target:type_name -> packets.SpectateTargetMessage
+	21, // 25: packets.Packet.finished_spectating:type_name -> packets.FinishedSpectatingMessage
+	22, // 26: packets.Packet.death:type_name -> packets.DeathMessage
+	23, // 27: packets.Packet.respawn_request:type_name -> packets.RespawnRequestMessage
+	24, // 28: packets.Packet.return_to_menu:type_name -> packets.ReturnToMenuMessage
+	26, // 29: packets.Packet.power_up:type_name -> packets.PowerUpMessage
+	27, // 30: packets.Packet.power_up_consumed:type_name -> packets.PowerUpConsumedMessage
+	28, // 31: packets.Packet.datagram_token:type_name -> packets.DatagramTokenMessage
+	29, // 32: packets.Packet.replay_request:type_name -> packets.ReplayRequestMessage
+	33, // [33:33] is the sub-list for method output_type
+	33, // [33:33] is the sub-list for method input_type
+	33, // [33:33] is the sub-list for extension type_name
+	33, // [33:33] is the sub-list for extension extendee
+	0,  // [0:33] is the sub-list for field type_name
 }
 
 func init() { file_packets_proto_init() }
@@ -1479,7 +2408,7 @@ func file_packets_proto_init() {
 	if File_packets_proto != nil {
 		return
 	}
-	file_packets_proto_msgTypes[18].OneofWrappers = []any{
+	file_packets_proto_msgTypes[29].OneofWrappers = []any{
 		(*Packet_Chat)(nil),
 		(*Packet_Id)(nil),
 		(*Packet_LoginRequest)(nil),
@@ -1498,19 +2427,30 @@ func file_packets_proto_init() {
 		(*Packet_FinishedBrowsingHiscores)(nil),
 		(*Packet_SearchHiscore)(nil),
 		(*Packet_Disconnect)(nil),
+		(*Packet_SpectateRequest)(nil),
+		(*Packet_SpectateTarget)(nil),
+		(*Packet_FinishedSpectating)(nil),
+		(*Packet_Death)(nil),
+		(*Packet_RespawnRequest)(nil),
+		(*Packet_ReturnToMenu)(nil),
+		(*Packet_PowerUp)(nil),
+		(*Packet_PowerUpConsumed)(nil),
+		(*Packet_DatagramToken)(nil),
+		(*Packet_ReplayRequest)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_packets_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   19,
+			NumEnums:      1,
+			NumMessages:   30,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
 		GoTypes:           file_packets_proto_goTypes,
 		DependencyIndexes: file_packets_proto_depIdxs,
+		EnumInfos:         file_packets_proto_enumTypes,
 		MessageInfos:      file_packets_proto_msgTypes,
 	}.Build()
 	File_packets_proto = out.File
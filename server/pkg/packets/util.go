@@ -1,9 +1,22 @@
 package packets
 
-import "server/internal/server/objects"
+import (
+	"server/internal/server/objects"
+	"time"
+)
 
 type Msg = isPacket_Msg
 
+// The name of the message's field in the packet, e.g. "player_direction", or "none" if the packet is empty
+func MessageType(packet *Packet) string {
+	message := packet.ProtoReflect()
+	field := message.WhichOneof(message.Descriptor().Oneofs().ByName("msg"))
+	if field == nil {
+		return "none"
+	}
+	return string(field.Name())
+}
+
 func NewChat(msg string) Msg {
 	return &Packet_Chat{
 		Chat: &ChatMessage{
@@ -31,21 +44,44 @@ func NewDenyResponse(reason string) Msg {
 	}
 }
 
-func NewPlayer(id uint64, player *objects.Player) Msg {
+// Effects and spawn protection are given as they stand at now, going by the game's clock
+func NewPlayer(id uint64, player *objects.Player, now time.Time) Msg {
 	return &Packet_Player{
 		Player: &PlayerMessage{
-			Id:        id,
-			Name:      player.Name,
-			X:         player.X,
-			Y:         player.Y,
-			Radius:    player.Radius,
-			Direction: player.Direction,
-			Speed:     player.Speed,
-			Color:     player.Color,
+			Id:                  id,
+			Name:                player.Name,
+			X:                   player.X,
+			Y:                   player.Y,
+			Radius:              player.Radius,
+			Direction:           player.Direction,
+			Speed:               player.Speed,
+			Color:               player.Color,
+			IsBot:               player.IsBot,
+			SpawnProtected:      player.IsSpawnProtected(now),
+			Effects:             newEffectMessages(player, now),
+			LastInputSequence:   player.InputSequence,
+			LastInputClientTime: player.InputClientTime,
 		},
 	}
 }
 
+func newEffectMessages(player *objects.Player, now time.Time) []*EffectMessage {
+	effects := player.Effects
+	effectMessages := make([]*EffectMessage, 0, len(effects))
+
+	for _, kind := range objects.PowerUpKinds {
+		if _, active := effects[kind]; !active {
+			continue
+		}
+		effectMessages = append(effectMessages, &EffectMessage{
+			Kind:      PowerUpKind(kind),
+			Remaining: player.EffectRemaining(kind, now).Seconds(),
+		})
+	}
+
+	return effectMessages
+}
+
 func newSporeMessage(id uint64, spore *objects.Spore) *SporeMessage {
 	return &SporeMessage{
 		Id:     id,
@@ -90,3 +126,42 @@ func NewDisconnect(reson string) Msg {
 		},
 	}
 }
+
+func NewSpectateTarget(playerId uint64) Msg {
+	return &Packet_SpectateTarget{
+		SpectateTarget: &SpectateTargetMessage{
+			PlayerId: playerId,
+		},
+	}
+}
+
+func NewDeath(killerId uint64, killerName string, finalMass uint64, timeAlive float64) Msg {
+	return &Packet_Death{
+		Death: &DeathMessage{
+			KillerId:   killerId,
+			KillerName: killerName,
+			FinalMass:  finalMass,
+			TimeAlive:  timeAlive,
+		},
+	}
+}
+
+func NewPowerUp(id uint64, powerUp *objects.PowerUp) Msg {
+	return &Packet_PowerUp{
+		PowerUp: &PowerUpMessage{
+			Id:     id,
+			X:      powerUp.X,
+			Y:      powerUp.Y,
+			Radius: powerUp.Radius,
+			Kind:   PowerUpKind(powerUp.Kind),
+		},
+	}
+}
+
+func NewDatagramToken(token uint64) Msg {
+	return &Packet_DatagramToken{
+		DatagramToken: &DatagramTokenMessage{
+			Token: token,
+		},
+	}
+}